@@ -28,6 +28,7 @@ import (
 type SandboxV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	SandboxesGetter
+	SandboxBackendsGetter
 	SandboxTemplatesGetter
 }
 
@@ -40,6 +41,10 @@ func (c *SandboxV1alpha1Client) Sandboxes(namespace string) SandboxInterface {
 	return newSandboxes(c, namespace)
 }
 
+func (c *SandboxV1alpha1Client) SandboxBackends() SandboxBackendInterface {
+	return newSandboxBackends(c)
+}
+
 func (c *SandboxV1alpha1Client) SandboxTemplates() SandboxTemplateInterface {
 	return newSandboxTemplates(c)
 }
@@ -31,6 +31,10 @@ func (c *FakeSandboxV1alpha1) Sandboxes(namespace string) v1alpha1.SandboxInterf
 	return &FakeSandboxes{c, namespace}
 }
 
+func (c *FakeSandboxV1alpha1) SandboxBackends() v1alpha1.SandboxBackendInterface {
+	return &FakeSandboxBackends{c}
+}
+
 func (c *FakeSandboxV1alpha1) SandboxTemplates() v1alpha1.SandboxTemplateInterface {
 	return &FakeSandboxTemplates{c}
 }
@@ -19,4 +19,6 @@ package v1alpha1
 
 type SandboxExpansion interface{}
 
+type SandboxBackendExpansion interface{}
+
 type SandboxTemplateExpansion interface{}
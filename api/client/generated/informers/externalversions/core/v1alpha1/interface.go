@@ -25,6 +25,8 @@ import (
 type Interface interface {
 	// Sandboxes returns a SandboxInformer.
 	Sandboxes() SandboxInformer
+	// SandboxBackends returns a SandboxBackendInformer.
+	SandboxBackends() SandboxBackendInformer
 	// SandboxTemplates returns a SandboxTemplateInformer.
 	SandboxTemplates() SandboxTemplateInformer
 }
@@ -45,6 +47,11 @@ func (v *version) Sandboxes() SandboxInformer {
 	return &sandboxInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SandboxBackends returns a SandboxBackendInformer.
+func (v *version) SandboxBackends() SandboxBackendInformer {
+	return &sandboxBackendInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SandboxTemplates returns a SandboxTemplateInformer.
 func (v *version) SandboxTemplates() SandboxTemplateInformer {
 	return &sandboxTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -25,6 +25,10 @@ type SandboxListerExpansion interface{}
 // SandboxNamespaceLister.
 type SandboxNamespaceListerExpansion interface{}
 
+// SandboxBackendListerExpansion allows custom methods to be added to
+// SandboxBackendLister.
+type SandboxBackendListerExpansion interface{}
+
 // SandboxTemplateListerExpansion allows custom methods to be added to
 // SandboxTemplateLister.
 type SandboxTemplateListerExpansion interface{}
@@ -106,6 +106,9 @@ func asyncSubresourceHelper(
 	case err = <-errChan:
 		return nil, err
 	case ws := <-aws.Connection:
+		if ws.Subprotocol() == ChannelStreamProtocolName {
+			return newChannelStreamer(ws, done), nil
+		}
 		return newWebsocketStreamer(ws, done), nil
 	}
 }
@@ -123,7 +126,7 @@ func roundTripperFromConfig(config *rest.Config, callback RoundTripCallback) (ht
 		TLSClientConfig: tlsConfig,
 		WriteBufferSize: WebsocketMessageBufferSize,
 		ReadBufferSize:  WebsocketMessageBufferSize,
-		Subprotocols:    []string{PlainStreamProtocolName},
+		Subprotocols:    []string{ChannelStreamProtocolName, PlainStreamProtocolName, ResizeStreamProtocolName},
 	}
 
 	// Create a roundtripper which will pass in the final underlying websocket connection to a callback
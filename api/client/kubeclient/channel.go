@@ -0,0 +1,201 @@
+package kubeclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// ChannelStreamProtocolName is the multiplexed protocol of kubectl, the server selects it for the pod sandboxes.
+// The first byte of the message is the channel: stdin, stdout, stderr, the error channel with the exit status and the resize channel.
+const ChannelStreamProtocolName = remotecommand.StreamProtocolV5Name
+
+func newChannelStreamer(conn *websocket.Conn, done chan struct{}) *channelStreamer {
+	return &channelStreamer{
+		conn: conn,
+		done: done,
+	}
+}
+
+type channelStreamer struct {
+	conn *websocket.Conn
+	done chan struct{}
+	mu   sync.Mutex
+}
+
+// Stream returns when the status is received or the connection is closed.
+// The non-zero exit code of the command is returned as exec.CodeExitError.
+func (cs *channelStreamer) Stream(options StreamOptions) error {
+	defer close(cs.done)
+
+	errChan := make(chan error, 3)
+	if options.In != nil {
+		go func() {
+			_, err := io.Copy(&channelWriter{streamer: cs, channel: remotecommand.StreamStdIn}, options.In)
+			if err == nil {
+				// The end of the input is signaled to the server, the command gets EOF.
+				err = cs.write(remotecommand.StreamClose, []byte{remotecommand.StreamStdIn})
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}()
+	}
+	if options.SizeQueue != nil {
+		go func() {
+			for size := options.SizeQueue.Next(); size != nil; size = options.SizeQueue.Next() {
+				data, err := json.Marshal(size)
+				if err != nil {
+					return
+				}
+				if err = cs.write(remotecommand.StreamResize, data); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		errChan <- cs.read(options)
+	}()
+
+	return <-errChan
+}
+
+func (cs *channelStreamer) read(options StreamOptions) error {
+	stderr := options.Err
+	if stderr == nil {
+		stderr = options.Out
+	}
+
+	for {
+		msgType, data, err := cs.conn.ReadMessage()
+		if err != nil {
+			if err = convert(err); errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msgType != websocket.BinaryMessage || len(data) < 2 {
+			continue
+		}
+
+		switch data[0] {
+		case remotecommand.StreamStdOut:
+			if options.Out != nil {
+				if _, err = options.Out.Write(data[1:]); err != nil {
+					return err
+				}
+			}
+		case remotecommand.StreamStdErr:
+			if stderr != nil {
+				if _, err = stderr.Write(data[1:]); err != nil {
+					return err
+				}
+			}
+		case remotecommand.StreamErr:
+			return decodeStatus(data[1:])
+		}
+	}
+}
+
+func (cs *channelStreamer) write(channel byte, data []byte) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+}
+
+// decodeStatus converts the status of the error channel to the error.
+func decodeStatus(data []byte) error {
+	status := &metav1.Status{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return fmt.Errorf("failed to decode the status %q: %w", string(data), err)
+	}
+	if status.Status == metav1.StatusSuccess {
+		return nil
+	}
+	if status.Reason == remotecommand.NonZeroExitCodeReason && status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			if cause.Type != remotecommand.ExitCodeCauseType {
+				continue
+			}
+			code, err := strconv.Atoi(cause.Message)
+			if err != nil {
+				return fmt.Errorf("invalid exit code %q: %w", cause.Message, err)
+			}
+			return exec.CodeExitError{Err: errors.New(status.Message), Code: code}
+		}
+	}
+	return apierrors.FromObject(status)
+}
+
+func (cs *channelStreamer) AsConn() net.Conn {
+	return &channelConn{
+		Conn:     cs.conn,
+		streamer: cs,
+	}
+}
+
+type channelWriter struct {
+	streamer *channelStreamer
+	channel  byte
+}
+
+func (w *channelWriter) Write(p []byte) (int, error) {
+	if err := w.streamer.write(w.channel, p); err != nil {
+		return 0, convert(err)
+	}
+	return len(p), nil
+}
+
+// channelConn reads stdout and writes stdin of the channel stream.
+type channelConn struct {
+	*websocket.Conn
+	streamer *channelStreamer
+	pending  []byte
+}
+
+func (c *channelConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		msgType, data, err := c.Conn.ReadMessage()
+		if err != nil {
+			return 0, convert(err)
+		}
+		if msgType != websocket.BinaryMessage || len(data) < 2 {
+			continue
+		}
+		switch data[0] {
+		case remotecommand.StreamStdOut:
+			c.pending = data[1:]
+		case remotecommand.StreamErr:
+			if err = decodeStatus(data[1:]); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (c *channelConn) Write(p []byte) (int, error) {
+	return (&channelWriter{streamer: c.streamer, channel: remotecommand.StreamStdIn}).Write(p)
+}
+
+func (c *channelConn) SetDeadline(t time.Time) error {
+	if err := c.Conn.SetWriteDeadline(t); err != nil {
+		return err
+	}
+	return c.Conn.SetReadDeadline(t)
+}
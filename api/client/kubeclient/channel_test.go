@@ -0,0 +1,90 @@
+package kubeclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/exec"
+)
+
+func TestDecodeStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantNil    bool
+		wantCode   int
+		wantStatus int32
+		wantErr    bool
+	}{
+		{
+			name:    "success",
+			data:    `{"metadata":{},"status":"Success"}`,
+			wantNil: true,
+		},
+		{
+			name:     "non-zero exit code",
+			data:     `{"metadata":{},"status":"Failure","message":"command terminated with non-zero exit code","reason":"NonZeroExitCode","details":{"causes":[{"reason":"ExitCode","message":"42"}]}}`,
+			wantCode: 42,
+		},
+		{
+			name:     "exit code after the other causes",
+			data:     `{"metadata":{},"status":"Failure","reason":"NonZeroExitCode","details":{"causes":[{"reason":"Other","message":"x"},{"reason":"ExitCode","message":"2"}]}}`,
+			wantCode: 2,
+		},
+		{
+			name:    "invalid exit code",
+			data:    `{"metadata":{},"status":"Failure","reason":"NonZeroExitCode","details":{"causes":[{"reason":"ExitCode","message":"forty-two"}]}}`,
+			wantErr: true,
+		},
+		{
+			name:       "non-zero exit code without the causes",
+			data:       `{"metadata":{},"status":"Failure","message":"terminated","reason":"NonZeroExitCode","code":500}`,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "failure",
+			data:       `{"metadata":{},"status":"Failure","message":"container not found","reason":"NotFound","code":404}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:    "invalid json",
+			data:    `not a status`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeStatus([]byte(tt.data))
+			if tt.wantNil {
+				if err != nil {
+					t.Fatalf("decodeStatus() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("decodeStatus() = nil, want error")
+			}
+
+			var exitErr exec.CodeExitError
+			isExit := errors.As(err, &exitErr)
+			switch {
+			case tt.wantCode != 0:
+				if !isExit || exitErr.Code != tt.wantCode {
+					t.Errorf("decodeStatus() = %v, want exit code %d", err, tt.wantCode)
+				}
+			case tt.wantStatus != 0:
+				var statusErr apierrors.APIStatus
+				if isExit || !errors.As(err, &statusErr) || statusErr.Status().Code != tt.wantStatus {
+					t.Errorf("decodeStatus() = %v, want status code %d", err, tt.wantStatus)
+				}
+			case tt.wantErr:
+				if isExit {
+					t.Errorf("decodeStatus() = %v, want decoding error", err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package kubeclient
 
 import (
+	"context"
 	"io"
 	"net"
 
@@ -9,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/client/generated/clientset/versioned"
 	sandboxv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/clientset/versioned/typed/core/v1alpha1"
@@ -40,12 +42,19 @@ func init() {
 type Client interface {
 	RESTConfig() *rest.Config
 	RESTClient() *rest.RESTClient
+	// Clientset is the generated clientset, it feeds the generated informers.
+	Clientset() versioned.Interface
 	Sandboxes(namespace string) SandboxInterface
 	SandboxTemplates() sandboxv1alpha1.SandboxTemplateInterface
 }
 type SandboxInterface interface {
 	sandboxv1alpha1.SandboxInterface
 	Attach(name string, options *subv1alpha1.Attach) (StreamInterface, error)
+	Exec(name string, options *subv1alpha1.Exec) (StreamInterface, error)
+	VNC(name string, options *subv1alpha1.VNC) (StreamInterface, error)
+	Recordings(ctx context.Context, name string) (*subv1alpha1.SessionRecordingList, error)
+	Recording(ctx context.Context, name, session string) (io.ReadCloser, error)
+	Sessions(ctx context.Context, name string) (*subv1alpha1.AttachSessionList, error)
 }
 
 type StreamInterface interface {
@@ -55,6 +64,10 @@ type StreamInterface interface {
 type StreamOptions struct {
 	In  io.Reader
 	Out io.Writer
+	// Err receives stderr of the channel stream, it is written to Out if it is nil.
+	Err io.Writer
+	// SizeQueue is the queue of the terminal sizes, they are sent to the server if it supports the resize.
+	SizeQueue remotecommand.TerminalSizeQueue
 }
 
 type client struct {
@@ -72,6 +85,10 @@ func (c client) RESTClient() *rest.RESTClient {
 	return c.restClient
 }
 
+func (c client) Clientset() versioned.Interface {
+	return c.sandboxClient
+}
+
 func (c client) Sandboxes(namespace string) SandboxInterface {
 	return &sandbox{
 		config:           c.config,
@@ -1,10 +1,13 @@
 package kubeclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"k8s.io/client-go/rest"
@@ -27,8 +30,15 @@ type connectionStruct struct {
 }
 
 func (s sandbox) Attach(name string, options *subv1alpha1.Attach) (StreamInterface, error) {
+	queryParams := url.Values{}
+	if options != nil && options.Component != "" {
+		queryParams.Set("component", options.Component)
+	}
+	if options != nil && options.Mode != "" {
+		queryParams.Set("mode", string(options.Mode))
+	}
 	if options == nil || options.ConnectionTimeout.Duration == 0 {
-		return asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "attach", url.Values{})
+		return asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "attach", queryParams)
 	}
 
 	ticker := time.NewTicker(options.ConnectionTimeout.Duration)
@@ -46,7 +56,7 @@ func (s sandbox) Attach(name string, options *subv1alpha1.Attach) (StreamInterfa
 			default:
 			}
 
-			con, err := asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "attach", url.Values{})
+			con, err := asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "attach", queryParams)
 			if err != nil {
 				var asyncSubresourceError *AsyncSubresourceError
 				ok := errors.As(err, &asyncSubresourceError)
@@ -67,3 +77,52 @@ func (s sandbox) Attach(name string, options *subv1alpha1.Attach) (StreamInterfa
 	conStruct := <-connectionChan
 	return conStruct.con, conStruct.err
 }
+
+// Exec executes the command in the pod sandbox, the stream returns exec.CodeExitError if the command fails.
+func (s sandbox) Exec(name string, options *subv1alpha1.Exec) (StreamInterface, error) {
+	queryParams := url.Values{}
+	if options.Component != "" {
+		queryParams.Set("component", options.Component)
+	}
+	for _, arg := range options.Command {
+		queryParams.Add("command", arg)
+	}
+	queryParams.Set("stdin", strconv.FormatBool(options.Stdin))
+	queryParams.Set("stdout", strconv.FormatBool(options.Stdout))
+	queryParams.Set("stderr", strconv.FormatBool(options.Stderr))
+	queryParams.Set("tty", strconv.FormatBool(options.TTY))
+	return asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "exec", queryParams)
+}
+
+func (s sandbox) VNC(name string, options *subv1alpha1.VNC) (StreamInterface, error) {
+	queryParams := url.Values{}
+	if options != nil && options.Component != "" {
+		queryParams.Set("component", options.Component)
+	}
+	return asyncSubresourceHelper(s.config, s.resource, s.namespace, name, "vnc", queryParams)
+}
+
+func (s sandbox) Recordings(ctx context.Context, name string) (*subv1alpha1.SessionRecordingList, error) {
+	list := &subv1alpha1.SessionRecordingList{}
+	err := s.restClient.Get().
+		AbsPath(fmt.Sprintf(subresourceURLTpl, s.namespace, s.resource, name, "recordings")).
+		Do(ctx).
+		Into(list)
+	return list, err
+}
+
+func (s sandbox) Sessions(ctx context.Context, name string) (*subv1alpha1.AttachSessionList, error) {
+	list := &subv1alpha1.AttachSessionList{}
+	err := s.restClient.Get().
+		AbsPath(fmt.Sprintf(subresourceURLTpl, s.namespace, s.resource, name, "sessions")).
+		Do(ctx).
+		Into(list)
+	return list, err
+}
+
+func (s sandbox) Recording(ctx context.Context, name, session string) (io.ReadCloser, error) {
+	return s.restClient.Get().
+		AbsPath(fmt.Sprintf(subresourceURLTpl, s.namespace, s.resource, name, "recordings")).
+		Param("session", session).
+		Stream(ctx)
+}
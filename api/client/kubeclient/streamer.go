@@ -1,10 +1,14 @@
 package kubeclient
 
 import (
+	"encoding/json"
+	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 func newWebsocketStreamer(conn *websocket.Conn, done chan struct{}) *wsStreamer {
@@ -17,6 +21,7 @@ func newWebsocketStreamer(conn *websocket.Conn, done chan struct{}) *wsStreamer
 type wsStreamer struct {
 	conn *websocket.Conn
 	done chan struct{}
+	mu   sync.Mutex
 }
 
 func (ws *wsStreamer) streamDone() {
@@ -27,10 +32,20 @@ func (ws *wsStreamer) Stream(options StreamOptions) error {
 	copyErr := make(chan error, 1)
 
 	go func() {
-		_, err := CopyTo(ws.conn, options.In)
+		_, err := io.Copy(&binaryWriter{conn: ws.conn, mu: &ws.mu}, options.In)
 		copyErr <- err
 	}()
 
+	if options.SizeQueue != nil && ws.conn.Subprotocol() == ResizeStreamProtocolName {
+		go func() {
+			for size := options.SizeQueue.Next(); size != nil; size = options.SizeQueue.Next() {
+				if err := ws.resize(size); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
 	go func() {
 		_, err := CopyFrom(options.Out, ws.conn)
 		copyErr <- err
@@ -40,6 +55,21 @@ func (ws *wsStreamer) Stream(options StreamOptions) error {
 	return <-copyErr
 }
 
+func (ws *wsStreamer) resize(size *remotecommand.TerminalSize) error {
+	msg, err := json.Marshal(ControlMessage{
+		Type:   ControlMessageResize,
+		Width:  size.Width,
+		Height: size.Height,
+	})
+	if err != nil {
+		return err
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.conn.WriteMessage(websocket.TextMessage, msg)
+}
+
 func (ws *wsStreamer) AsConn() net.Conn {
 	return &wsConn{
 		Conn:         ws.conn,
@@ -22,12 +22,26 @@ package kubeclient
 import (
 	"errors"
 	"io"
+	"sync"
 
 	"github.com/gorilla/websocket"
 )
 
 const PlainStreamProtocolName = "plain.sandbox.io"
 
+// ResizeStreamProtocolName is the protocol of the stream, which accepts the control messages besides the binary data.
+// The control messages are sent as the text messages, the server selects the protocol if it supports them.
+const ResizeStreamProtocolName = "resize.sandbox.io"
+
+const ControlMessageResize = "resize"
+
+// ControlMessage is the control message of the ResizeStreamProtocolName protocol.
+type ControlMessage struct {
+	Type   string `json:"type"`
+	Width  uint16 `json:"width,omitempty"`
+	Height uint16 `json:"height,omitempty"`
+}
+
 const (
 	WebsocketMessageBufferSize = 10240
 )
@@ -42,9 +56,15 @@ func CopyTo(dst *websocket.Conn, src io.Reader) (written int64, err error) {
 
 type binaryWriter struct {
 	conn *websocket.Conn
+	// mu serializes the writes, if the connection is shared with the control messages.
+	mu *sync.Mutex
 }
 
 func (s *binaryWriter) Write(p []byte) (int, error) {
+	if s.mu != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
 	w, err := s.conn.NextWriter(websocket.BinaryMessage)
 	if err != nil {
 		return 0, convert(err)
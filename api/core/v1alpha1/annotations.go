@@ -0,0 +1,9 @@
+package v1alpha1
+
+const (
+	// AnnotationSandboxOwner is the name of the user who created the sandbox, it is set on creation and immutable.
+	AnnotationSandboxOwner = "sandbox.io/owner"
+
+	// LabelSandboxUID is the UID of the sandbox, the controller sets it on the child resources of the sandbox.
+	LabelSandboxUID = "sandbox.io/uid"
+)
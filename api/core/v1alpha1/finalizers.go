@@ -2,4 +2,6 @@ package v1alpha1
 
 const (
 	FinalizerProtectBySandboxController = "sandbox.io/protect-by-sandbox-controller"
+	// FinalizerSandboxBackendConnection lets the controller close the connection to the plugin server of the deleted SandboxBackend.
+	FinalizerSandboxBackendConnection = "sandbox.io/sandboxbackend-connection"
 )
@@ -39,6 +39,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Sandbox{},
 		&SandboxList{},
+		&SandboxBackend{},
+		&SandboxBackendList{},
 		&SandboxTemplate{},
 		&SandboxTemplateList{},
 	)
@@ -21,5 +21,6 @@ const (
 	ReasonReady       Reason = "Ready"
 	ReasonPending     Reason = "Pending"
 	ReasonFailed      Reason = "Failed"
+	ReasonSucceeded   Reason = "Succeeded"
 	ReasonTerminating Reason = "Terminating"
 )
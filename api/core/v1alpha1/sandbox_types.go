@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +16,7 @@ const SandboxKind = "Sandbox"
 // +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".spec.template",description="Sandbox template name."
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".status.type",description="Sandbox type."
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Sandbox status."
+// +kubebuilder:printcolumn:name="Exit Code",type="integer",JSONPath=".status.result.exitCode",description="Exit code of the finished sandbox.",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time of resource creation."
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -36,22 +39,97 @@ type SandboxSpec struct {
 	// TTL is the time after which the sandbox will be automatically deleted
 	// +kubebuilder:validation:Format=duration
 	TTL metav1.Duration `json:"ttl,omitempty"`
+	// Access is the access configuration of the sandbox.
+	Access *SandboxAccess `json:"access,omitempty"`
+}
+
+type SandboxAccess struct {
+	// SSHKeys is the list of public SSH keys to authorize in the virtual machine sandboxes.
+	// The keys are injected into the cloud-init user data of the virtual machine.
+	SSHKeys []string `json:"sshKeys,omitempty"`
 }
 
 type SandboxStatus struct {
 	Type       SandboxType        `json:"type,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Result is the result of the finished sandbox in the run-to-completion mode.
+	Result *SandboxResult `json:"result,omitempty"`
+	// Namespace is the dedicated namespace of the child resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Components is the status of the components of the multi-component sandbox.
+	Components []SandboxComponentStatus `json:"components,omitempty"`
+	// Volumes is the provisioning status of the volumes of the sandbox.
+	Volumes []SandboxVolumeStatus `json:"volumes,omitempty"`
+}
+
+type SandboxVolumeStatus struct {
+	// Name is the name of the volume in the template.
+	Name string `json:"name"`
+	// Component is the name of the component, which the volume belongs to.
+	Component string `json:"component,omitempty"`
+	// Kind is the kind of the volume resource: PersistentVolumeClaim, DataVolume or VirtualDisk.
+	Kind string `json:"kind"`
+	// ResourceName is the name of the volume resource.
+	ResourceName string `json:"resourceName"`
+	// Phase is the phase of the volume resource.
+	Phase string `json:"phase,omitempty"`
+	// Progress is the provisioning progress of the volume resource, for example `45.00%`.
+	Progress string `json:"progress,omitempty"`
+	// Ready reports the volume is provisioned.
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"`
+}
+
+type SandboxComponentStatus struct {
+	Name    string                 `json:"name"`
+	Type    SandboxType            `json:"type,omitempty"`
+	Status  metav1.ConditionStatus `json:"status,omitempty"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+type SandboxResult struct {
+	Phase SandboxResultPhase `json:"phase"`
+	// ExitCode is the exit code of the first container, it is not set for the virtual machines.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Message is the termination message of the workload.
+	Message    string      `json:"message,omitempty"`
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
+	// ArtifactsClaimName is the name of the retained persistent volume claim with the collected files.
+	ArtifactsClaimName string `json:"artifactsClaimName,omitempty"`
 }
 
-// +kubebuilder:validation:Enum:={"", Pod,DVP/VirtualMachine,Kubevirt/VirtualMachineInstance}
+// +kubebuilder:validation:Enum:={Succeeded,Failed}
+type SandboxResultPhase string
+
+const (
+	SandboxResultPhaseSucceeded SandboxResultPhase = "Succeeded"
+	SandboxResultPhaseFailed    SandboxResultPhase = "Failed"
+)
+
+// SandboxType is one of the built-in types or `Plugin/<SandboxBackend name>` for the plugin-owned sandboxes.
+// +kubebuilder:validation:XValidation:rule="size(self) == 0 || self in ['Pod', 'DVP/VirtualMachine', 'Kubevirt/VirtualMachineInstance', 'Unstructured', 'Composite'] || self.startsWith('Plugin/')",message="Unknown sandbox type"
 type SandboxType string
 
 const (
-	SandboxTypePod         SandboxType = "Pod"
-	SandboxTypeDVPVM       SandboxType = "DVP/VirtualMachine"
-	SandboxTypeKubevirtVMI SandboxType = "Kubevirt/VirtualMachineInstance"
+	SandboxTypePod          SandboxType = "Pod"
+	SandboxTypeDVPVM        SandboxType = "DVP/VirtualMachine"
+	SandboxTypeKubevirtVMI  SandboxType = "Kubevirt/VirtualMachineInstance"
+	SandboxTypeUnstructured SandboxType = "Unstructured"
+	SandboxTypeComposite    SandboxType = "Composite"
 )
 
+const SandboxTypePluginPrefix = "Plugin/"
+
+func NewPluginSandboxType(backend string) SandboxType {
+	return SandboxType(SandboxTypePluginPrefix + backend)
+}
+
+// PluginBackend returns the name of the SandboxBackend if the type is plugin-owned.
+func (t SandboxType) PluginBackend() (string, bool) {
+	return strings.CutPrefix(string(t), SandboxTypePluginPrefix)
+}
+
 // The SandboxList resource describes a list of Sandbox resources.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type SandboxList struct {
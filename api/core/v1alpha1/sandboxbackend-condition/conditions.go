@@ -0,0 +1,23 @@
+package sandboxbackendcondition
+
+// Type represents the various condition types for the `SandboxBackend`.
+type Type string
+
+func (s Type) String() string {
+	return string(s)
+}
+
+const (
+	TypeReady Type = "Ready"
+)
+
+type Reason string
+
+func (s Reason) String() string {
+	return string(s)
+}
+
+const (
+	ReasonReady       Reason = "Ready"
+	ReasonUnavailable Reason = "Unavailable"
+)
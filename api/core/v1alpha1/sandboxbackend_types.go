@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const SandboxBackendKind = "SandboxBackend"
+
+// The SandboxBackend resource describes an external plugin server, which manages sandboxes of a custom type.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={sandbox-mommy},scope=Cluster,shortName={sbb,sbbs},singular=sandboxbackend
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint",description="SandboxBackend endpoint."
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="SandboxBackend status."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time of resource creation."
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SandboxBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SandboxBackendSpec   `json:"spec,omitempty"`
+	Status SandboxBackendStatus `json:"status,omitempty"`
+}
+
+type SandboxBackendSpec struct {
+	// Endpoint is the gRPC address of the plugin server,
+	// e.g. `unix:///var/run/sandbox-plugins/acme.sock` or `dns:///acme-plugin.acme.svc:9000`.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// Timeout is the timeout of a single call to the plugin server.
+	// +kubebuilder:validation:Format=duration
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// TLS configures the connection to the plugin server, the connection uses TLS with the system roots by default.
+	TLS *SandboxBackendTLS `json:"tls,omitempty"`
+}
+
+type SandboxBackendTLS struct {
+	// Insecure disables TLS, the sandboxes are sent to the plugin server in plaintext.
+	// It is the explicit opt-in, for example for the plugin server on the unix socket.
+	Insecure bool `json:"insecure,omitempty"`
+	// CABundle is the PEM-encoded CA bundle to verify the certificate of the plugin server.
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SecretRef refers to the secret with the CA bundle in `ca.crt` and the client certificate in `tls.crt` and `tls.key`.
+	SecretRef *SandboxBackendSecretReference `json:"secretRef,omitempty"`
+	// ServerName is the name to verify in the certificate of the plugin server, the host of the endpoint by default.
+	ServerName string `json:"serverName,omitempty"`
+}
+
+type SandboxBackendSecretReference struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+type SandboxBackendStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ProtocolVersion is the version of the plugin protocol reported by the plugin server.
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+}
+
+// The SandboxBackendList resource describes a list of SandboxBackend resources.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SandboxBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SandboxBackend `json:"items"`
+}
@@ -3,7 +3,9 @@ package v1alpha1
 import (
 	dvpcorev1alpha2 "github.com/deckhouse/virtualization/api/core/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	virtv1 "kubevirt.io/api/core/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
@@ -28,8 +30,8 @@ type SandboxTemplate struct {
 	Status SandboxTemplateStatus `json:"status,omitempty"`
 }
 
-// +kubebuilder:validation:XValidation:rule="has(self.podSpec) || has(self.kubevirtVMISpec) || has(self.dvpVMSpec)",message="Either podSpec,kubevirtVMISpec or dvpVMSpec must be specified"
-// +kubebuilder:validation:XValidation:rule="!(has(self.podSpec) && has(self.kubevirtVMISpec) && has(self.dvpVMSpec))",message="Only one of podSpec,kubevirtVMISpec or dvpVMSpecmust be specified"
+// +kubebuilder:validation:XValidation:rule="has(self.podSpec) || has(self.kubevirtVMISpec) || has(self.dvpVMSpec) || has(self.unstructuredSpec) || has(self.pluginSpec) || has(self.components)",message="Either podSpec, kubevirtVMISpec, dvpVMSpec, unstructuredSpec, pluginSpec or components must be specified"
+// +kubebuilder:validation:XValidation:rule="[has(self.podSpec), has(self.kubevirtVMISpec), has(self.dvpVMSpec), has(self.unstructuredSpec), has(self.pluginSpec), has(self.components)].filter(x, x).size() <= 1",message="Only one of podSpec, kubevirtVMISpec, dvpVMSpec, unstructuredSpec, pluginSpec or components must be specified"
 // +kubebuilder:validation:XValidation:rule="self == oldSelf",message=".spec is immutable"
 type SandboxTemplateSpec struct {
 	// PodSpec is the spec of the pod to run in the sandbox.
@@ -38,10 +40,115 @@ type SandboxTemplateSpec struct {
 	KubevirtVMISpec *virtv1.VirtualMachineInstanceSpec `json:"kubevirtVMISpec,omitempty"`
 	// DVPVMSpec is the spec of the dvp virtual machine to run in the sandbox.
 	DVPVMSpec *dvpcorev1alpha2.VirtualMachineSpec `json:"dvpVMSpec,omitempty"`
+	// UnstructuredSpec is the spec of the arbitrary resources to run in the sandbox.
+	UnstructuredSpec *UnstructuredSpec `json:"unstructuredSpec,omitempty"`
+	// PluginSpec is the spec of the sandbox managed by an external plugin server.
+	PluginSpec *PluginSpec `json:"pluginSpec,omitempty"`
+	// Components is the list of components, which run together in the sandbox.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Components []SandboxComponent `json:"components,omitempty"`
+	// Namespace is the namespace mode of the sandbox, `Dedicated` creates a fresh namespace for the child resources.
+	// +kubebuilder:validation:Enum:={Shared,Dedicated}
+	Namespace NamespaceMode `json:"namespace,omitempty"`
+	// DedicatedNamespace is the configuration of the dedicated namespace.
+	DedicatedNamespace *DedicatedNamespaceSpec `json:"dedicatedNamespace,omitempty"`
+	// Completion enables the run-to-completion mode, the finished workload is not restarted.
+	// Supported for the Pod and Kubevirt/VirtualMachineInstance types.
+	Completion *CompletionSpec `json:"completion,omitempty"`
 	// Volumes is the list of volumes to create and mount in the sandbox.
 	Volumes []SandboxVolumeSpec `json:"volumes,omitempty"`
 }
 
+type UnstructuredSpec struct {
+	// Manifests is the list of namespaced resources of any kind to create in the sandbox.
+	// Every resource is created in the sandbox namespace, its name is prefixed with `sandbox-<sandbox uid>-`.
+	// +kubebuilder:validation:MinItems=1
+	Manifests []runtime.RawExtension `json:"manifests"`
+	// ReadyExpression is the CEL expression that reports the sandbox is ready.
+	// The created resources are available in the expression as the `objects` list, in the order of manifests.
+	// +kubebuilder:validation:MinLength=1
+	ReadyExpression string `json:"readyExpression"`
+	// FailedExpression is the CEL expression that reports the sandbox is failed.
+	// The created resources are available in the expression as the `objects` list, in the order of manifests.
+	FailedExpression string `json:"failedExpression,omitempty"`
+}
+
+type PluginSpec struct {
+	// Backend is the name of the SandboxBackend which manages the sandbox.
+	// +kubebuilder:validation:MinLength=1
+	Backend string `json:"backend"`
+	// Spec is the plugin-owned spec of the sandbox, it is passed to the plugin server as is.
+	Spec runtime.RawExtension `json:"spec,omitempty"`
+}
+
+type NamespaceMode string
+
+const (
+	NamespaceModeShared    NamespaceMode = "Shared"
+	NamespaceModeDedicated NamespaceMode = "Dedicated"
+)
+
+type DedicatedNamespaceSpec struct {
+	// ResourceQuota is the spec of the resource quota of the namespace.
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange is the spec of the limit range of the namespace.
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+	// OwnerClusterRole is the cluster role, which is bound to the sandbox owner in the namespace.
+	// +kubebuilder:default:="edit"
+	OwnerClusterRole string `json:"ownerClusterRole,omitempty"`
+}
+
+// SandboxComponent is one of the workloads of the multi-component sandbox.
+// The backend specs and volumes are not validated by the schema to keep the size of the CRD bounded,
+// they are validated by the webhook.
+// +kubebuilder:validation:XValidation:rule="[has(self.podSpec), has(self.kubevirtVMISpec), has(self.dvpVMSpec)].filter(x, x).size() == 1",message="Exactly one of podSpec, kubevirtVMISpec or dvpVMSpec must be specified"
+type SandboxComponent struct {
+	// Name is the name of the component, it is a part of the names of the component resources.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=16
+	Name string `json:"name"`
+	// DependsOn is the list of components, which must be ready before the component is created.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// PodSpec is the spec of the pod of the component.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodSpec *corev1.PodSpec `json:"podSpec,omitempty"`
+	// KubevirtVMISpec is the spec of the kubevirt virtual machine instance of the component.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	KubevirtVMISpec *virtv1.VirtualMachineInstanceSpec `json:"kubevirtVMISpec,omitempty"`
+	// DVPVMSpec is the spec of the dvp virtual machine of the component.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	DVPVMSpec *dvpcorev1alpha2.VirtualMachineSpec `json:"dvpVMSpec,omitempty"`
+	// Volumes is the list of volumes of the component.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=array
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Volumes []SandboxVolumeSpec `json:"volumes,omitempty"`
+}
+
+type CompletionSpec struct {
+	// Artifacts is the configuration of the files collection, supported only for the Pod type.
+	Artifacts *ArtifactsSpec `json:"artifacts,omitempty"`
+}
+
+type ArtifactsSpec struct {
+	// Path is the absolute path, the files under which are collected.
+	// The retained persistent volume claim is mounted at this path into the containers.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+	// Size is the size of the persistent volume claim.
+	Size resource.Quantity `json:"size"`
+	// StorageClassName is the storage class of the persistent volume claim.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.pvcSpec) || has(self.dataVolumeSpec) || has(self.virtualDiskSpec)",message="Either pvcSpec, dataVolumeSpec or virtualDiskSpec must be specified"
 // +kubebuilder:validation:XValidation:rule="!(has(self.pvcSpec) && has(self.dataVolumeSpec) && has(self.virtualDiskSpec))",message="Only one of pvcSpec, dataVolumeSpec or virtualDiskSpec must be specified"
 type SandboxVolumeSpec struct {
@@ -29,6 +29,92 @@ import (
 	v1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactsSpec) DeepCopyInto(out *ArtifactsSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactsSpec.
+func (in *ArtifactsSpec) DeepCopy() *ArtifactsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompletionSpec) DeepCopyInto(out *CompletionSpec) {
+	*out = *in
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = new(ArtifactsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompletionSpec.
+func (in *CompletionSpec) DeepCopy() *CompletionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CompletionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedNamespaceSpec) DeepCopyInto(out *DedicatedNamespaceSpec) {
+	*out = *in
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(corev1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(corev1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedNamespaceSpec.
+func (in *DedicatedNamespaceSpec) DeepCopy() *DedicatedNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSpec) DeepCopyInto(out *PluginSpec) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSpec.
+func (in *PluginSpec) DeepCopy() *PluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sandbox) DeepCopyInto(out *Sandbox) {
 	*out = *in
@@ -57,6 +143,234 @@ func (in *Sandbox) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxAccess) DeepCopyInto(out *SandboxAccess) {
+	*out = *in
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxAccess.
+func (in *SandboxAccess) DeepCopy() *SandboxAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackend) DeepCopyInto(out *SandboxBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackend.
+func (in *SandboxBackend) DeepCopy() *SandboxBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SandboxBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackendList) DeepCopyInto(out *SandboxBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SandboxBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackendList.
+func (in *SandboxBackendList) DeepCopy() *SandboxBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SandboxBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackendSecretReference) DeepCopyInto(out *SandboxBackendSecretReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackendSecretReference.
+func (in *SandboxBackendSecretReference) DeepCopy() *SandboxBackendSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackendSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackendSpec) DeepCopyInto(out *SandboxBackendSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SandboxBackendTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackendSpec.
+func (in *SandboxBackendSpec) DeepCopy() *SandboxBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackendStatus) DeepCopyInto(out *SandboxBackendStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackendStatus.
+func (in *SandboxBackendStatus) DeepCopy() *SandboxBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxBackendTLS) DeepCopyInto(out *SandboxBackendTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SandboxBackendSecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxBackendTLS.
+func (in *SandboxBackendTLS) DeepCopy() *SandboxBackendTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxBackendTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxComponent) DeepCopyInto(out *SandboxComponent) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSpec != nil {
+		in, out := &in.PodSpec, &out.PodSpec
+		*out = new(corev1.PodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubevirtVMISpec != nil {
+		in, out := &in.KubevirtVMISpec, &out.KubevirtVMISpec
+		*out = new(apicorev1.VirtualMachineInstanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DVPVMSpec != nil {
+		in, out := &in.DVPVMSpec, &out.DVPVMSpec
+		*out = new(v1alpha2.VirtualMachineSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]SandboxVolumeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxComponent.
+func (in *SandboxComponent) DeepCopy() *SandboxComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxComponentStatus) DeepCopyInto(out *SandboxComponentStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxComponentStatus.
+func (in *SandboxComponentStatus) DeepCopy() *SandboxComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SandboxList) DeepCopyInto(out *SandboxList) {
 	*out = *in
@@ -90,6 +404,28 @@ func (in *SandboxList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxResult) DeepCopyInto(out *SandboxResult) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxResult.
+func (in *SandboxResult) DeepCopy() *SandboxResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SandboxSpec) DeepCopyInto(out *SandboxSpec) {
 	*out = *in
@@ -99,6 +435,11 @@ func (in *SandboxSpec) DeepCopyInto(out *SandboxSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.TTL = in.TTL
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(SandboxAccess)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -122,6 +463,21 @@ func (in *SandboxStatus) DeepCopyInto(out *SandboxStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(SandboxResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]SandboxComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]SandboxVolumeStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -214,6 +570,33 @@ func (in *SandboxTemplateSpec) DeepCopyInto(out *SandboxTemplateSpec) {
 		*out = new(v1alpha2.VirtualMachineSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UnstructuredSpec != nil {
+		in, out := &in.UnstructuredSpec, &out.UnstructuredSpec
+		*out = new(UnstructuredSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginSpec != nil {
+		in, out := &in.PluginSpec, &out.PluginSpec
+		*out = new(PluginSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]SandboxComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DedicatedNamespace != nil {
+		in, out := &in.DedicatedNamespace, &out.DedicatedNamespace
+		*out = new(DedicatedNamespaceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Completion != nil {
+		in, out := &in.Completion, &out.Completion
+		*out = new(CompletionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]SandboxVolumeSpec, len(*in))
@@ -287,3 +670,42 @@ func (in *SandboxVolumeSpec) DeepCopy() *SandboxVolumeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxVolumeStatus) DeepCopyInto(out *SandboxVolumeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxVolumeStatus.
+func (in *SandboxVolumeStatus) DeepCopy() *SandboxVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnstructuredSpec) DeepCopyInto(out *UnstructuredSpec) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnstructuredSpec.
+func (in *UnstructuredSpec) DeepCopy() *UnstructuredSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UnstructuredSpec)
+	in.DeepCopyInto(out)
+	return out
+}
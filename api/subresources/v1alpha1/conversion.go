@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// addConversionFuncs adds the conversions of the query parameters to the connect options.
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	if err := scheme.AddConversionFunc((*url.Values)(nil), (*Attach)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		return convertURLValuesToAttach(*a.(*url.Values), b.(*Attach))
+	}); err != nil {
+		return err
+	}
+	if err := scheme.AddConversionFunc((*url.Values)(nil), (*Exec)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		return convertURLValuesToExec(*a.(*url.Values), b.(*Exec))
+	}); err != nil {
+		return err
+	}
+	if err := scheme.AddConversionFunc((*url.Values)(nil), (*VNC)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*VNC).Component = a.(*url.Values).Get("component")
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := scheme.AddConversionFunc((*url.Values)(nil), (*ConsoleUI)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*ConsoleUI).Component = a.(*url.Values).Get("component")
+		b.(*ConsoleUI).Path = a.(*url.Values).Get("path")
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := scheme.AddConversionFunc((*url.Values)(nil), (*Recordings)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*Recordings).Session = a.(*url.Values).Get("session")
+		return nil
+	}); err != nil {
+		return err
+	}
+	return scheme.AddConversionFunc((*url.Values)(nil), (*Sessions)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*Sessions).Component = a.(*url.Values).Get("component")
+		return nil
+	})
+}
+
+func convertURLValuesToAttach(values url.Values, out *Attach) error {
+	out.Component = values.Get("component")
+	switch mode := AttachMode(values.Get("mode")); mode {
+	case "", AttachModeDrive, AttachModeObserve, AttachModeControl:
+		out.Mode = mode
+	default:
+		return fmt.Errorf("invalid mode %q, must be one of %q, %q or %q", mode, AttachModeDrive, AttachModeObserve, AttachModeControl)
+	}
+	if timeout := values.Get("connectionTimeout"); timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid connectionTimeout %q: %w", timeout, err)
+		}
+		out.ConnectionTimeout.Duration = duration
+	}
+	return nil
+}
+
+func convertURLValuesToExec(values url.Values, out *Exec) error {
+	out.Component = values.Get("component")
+	out.Command = values["command"]
+	for name, value := range map[string]*bool{
+		"stdin":  &out.Stdin,
+		"stdout": &out.Stdout,
+		"stderr": &out.Stderr,
+		"tty":    &out.TTY,
+	} {
+		if param := values.Get(name); param != "" {
+			parsed, err := strconv.ParseBool(param)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, param, err)
+			}
+			*value = parsed
+		}
+	}
+	return nil
+}
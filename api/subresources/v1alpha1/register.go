@@ -15,7 +15,7 @@ const (
 var (
 	SchemeGroupVersion = schema.GroupVersion{Group: subresources.GroupName, Version: Version}
 
-	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes, addConversionFuncs)
 
 	AddToScheme = SchemeBuilder.AddToScheme
 )
@@ -39,6 +39,13 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Sandbox{},
 		&Attach{},
+		&Exec{},
+		&VNC{},
+		&ConsoleUI{},
+		&Recordings{},
+		&SessionRecordingList{},
+		&Sessions{},
+		&AttachSessionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -15,4 +15,123 @@ type Attach struct {
 	metav1.TypeMeta `json:",inline"`
 
 	ConnectionTimeout metav1.Duration `json:"connectionTimeout,omitempty"`
+	// Component is the component of the multi-component sandbox to attach to.
+	Component string `json:"component,omitempty"`
+	// Mode is the mode of joining the shared session of the sandbox, drive is the default.
+	Mode AttachMode `json:"mode,omitempty"`
+}
+
+// AttachMode is the mode of joining the shared attach session.
+type AttachMode string
+
+const (
+	// AttachModeDrive starts the session or joins it, the input is accepted if the session has no driver.
+	AttachModeDrive AttachMode = "drive"
+	// AttachModeObserve joins the live session read-only.
+	AttachModeObserve AttachMode = "observe"
+	// AttachModeControl starts the session or joins it and takes control from the current driver.
+	AttachModeControl AttachMode = "control"
+)
+
+// Exec is the options of the command execution in the pod sandbox.
+// The streams are multiplexed with the v5.channel.k8s.io protocol, the exit status is sent to the error channel.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Exec struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Component is the component of the multi-component sandbox to execute the command in.
+	Component string `json:"component,omitempty"`
+	// Command is the command to execute.
+	Command []string `json:"command"`
+	// Stdin redirects the standard input stream of the command.
+	Stdin bool `json:"stdin,omitempty"`
+	// Stdout redirects the standard output stream of the command.
+	Stdout bool `json:"stdout,omitempty"`
+	// Stderr redirects the standard error stream of the command.
+	Stderr bool `json:"stderr,omitempty"`
+	// TTY allocates the terminal for the command.
+	TTY bool `json:"tty,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VNC struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Component is the component of the multi-component sandbox to connect to.
+	Component string `json:"component,omitempty"`
+}
+
+// ConsoleUI is the options of the web console, the console attaches to the sandbox of the request.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ConsoleUI struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Component is the component of the multi-component sandbox to attach to.
+	Component string `json:"component,omitempty"`
+	// Path is the subpath of the console, the vendored assets of the page are served under it.
+	Path string `json:"path,omitempty"`
+}
+
+// Recordings is the options of the recordings of the attach sessions.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Recordings struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Session is the identifier of the session to download, the list of the recordings is returned if it is empty.
+	Session string `json:"session,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SessionRecordingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SessionRecording `json:"items"`
+}
+
+// SessionRecording is the recording of the attach session in the asciinema v2 format.
+type SessionRecording struct {
+	// Session is the identifier of the session.
+	Session string `json:"session"`
+	// User is the name of the user, who attached to the sandbox.
+	User string `json:"user"`
+	// Component is the component of the multi-component sandbox.
+	Component string `json:"component,omitempty"`
+	// StartTime is the time of the session start.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time of the last recorded event.
+	EndTime metav1.Time `json:"endTime"`
+	// Size is the size of the recording in bytes.
+	Size int64 `json:"size"`
+}
+
+// Sessions is the options of the live attach sessions.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Sessions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Component filters the sessions by the component of the multi-component sandbox.
+	Component string `json:"component,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AttachSessionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AttachSession `json:"items"`
+}
+
+// AttachSession is the live attach session, which is shared by the connected clients.
+type AttachSession struct {
+	// Session is the identifier of the session, it is the identifier of the recording as well.
+	Session string `json:"session"`
+	// Component is the component of the multi-component sandbox.
+	Component string `json:"component,omitempty"`
+	// Driver is the name of the user, whose input is sent to the sandbox.
+	Driver string `json:"driver,omitempty"`
+	// Observers are the names of the users, who watch the session read-only.
+	Observers []string `json:"observers,omitempty"`
+	// StartTime is the time of the session start.
+	StartTime metav1.Time `json:"startTime"`
 }
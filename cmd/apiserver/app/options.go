@@ -9,7 +9,6 @@ import (
 	generatedopenapi "github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/api/generated"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/server"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
-	"k8s.io/apimachinery/pkg/types"
 	openapinamer "k8s.io/apiserver/pkg/endpoints/openapi"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
@@ -30,7 +29,8 @@ type Options struct {
 	Features       *genericoptions.FeatureOptions
 	Logging        *logs.Options
 
-	ServiceAccount types.NamespacedName
+	RecordingDir  string
+	ConsoleAssets string
 
 	ShowVersion bool
 	// Only to be used to for testing
@@ -44,8 +44,8 @@ func (o *Options) Validate() error {
 func (o *Options) Flags() (fs flag.NamedFlagSets) {
 	msfs := fs.FlagSet("sandbox-api server")
 	msfs.BoolVar(&o.ShowVersion, "version", false, "Show version")
-	msfs.StringVar(&o.ServiceAccount.Name, "service-account-name", "", "Service account name")
-	msfs.StringVar(&o.ServiceAccount.Namespace, "service-account-namespace", "", "Service account namespace")
+	msfs.StringVar(&o.RecordingDir, "session-recording-dir", "", "Directory to record the attach sessions in the asciinema v2 format, the recording is disabled if empty")
+	msfs.StringVar(&o.ConsoleAssets, "console-assets-url", "", "Base URL of the npm packages of the xterm.js assets of the web console, the vendored assets are served if empty. The assets loaded from the URL are checked against the vendored ones with the subresource integrity")
 
 	featuregate.AddFlags(fs.FlagSet("sabdbox-api feature-gates"))
 
@@ -82,9 +82,10 @@ func (o *Options) ServerConfig() (*server.Config, error) {
 	}
 
 	conf := &server.Config{
-		Apiserver:      apiserver,
-		Rest:           restConfig,
-		ServiceAccount: o.ServiceAccount,
+		Apiserver:     apiserver,
+		Rest:          restConfig,
+		RecordingDir:  o.RecordingDir,
+		ConsoleAssets: o.ConsoleAssets,
 	}
 	if err := conf.Validate(); err != nil {
 		return nil, err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	dvpcorev1alpha2 "github.com/deckhouse/virtualization/api/core/v1alpha2"
 	"github.com/spf13/cobra"
@@ -19,7 +20,9 @@ import (
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/controller/sandbox"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/controller/sandboxbackend"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/controller/sandboxtemplate"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/controller/sweeper"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
 	"github.com/yaroslavborbat/sandbox-mommy/pkg/config"
 	scontrollerutil "github.com/yaroslavborbat/sandbox-mommy/pkg/controller/util"
@@ -33,11 +36,14 @@ const (
 type sandboxControllerOptions struct {
 	Base           config.BaseOpts
 	LeaderElection bool
+	Sweeper        sweeper.Options
 }
 
 func (o *sandboxControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	o.Base.AddFlags(fs)
 	fs.BoolVar(&o.LeaderElection, "leader-election", true, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	fs.DurationVar(&o.Sweeper.Interval, "orphan-sweep-interval", 10*time.Minute, "The period of the sweeps of the orphaned child resources. Zero disables the sweeper.")
+	fs.BoolVar(&o.Sweeper.DryRun, "orphan-sweep-dry-run", false, "Report the orphaned child resources without the deletion.")
 	featuregate.AddFlags(fs)
 }
 
@@ -122,12 +128,18 @@ func runCommand(ctx context.Context, opts *sandboxControllerOptions) (err error)
 
 	log.Info("Registering Components.")
 
-	if err = sandbox.SetupController(mgr, log); err != nil {
+	if err = sandbox.SetupController(ctx, mgr, log); err != nil {
 		return fmt.Errorf("failed to setup Sandbox controller %w", err)
 	}
 	if err = sandboxtemplate.SetupController(mgr, log); err != nil {
 		return fmt.Errorf("failed to setup SandboxTemplate controller %w", err)
 	}
+	if err = sandboxbackend.SetupController(mgr, log); err != nil {
+		return fmt.Errorf("failed to setup SandboxBackend controller %w", err)
+	}
+	if err = sweeper.SetupSweeper(mgr, opts.Sweeper, log); err != nil {
+		return fmt.Errorf("failed to setup orphan sweeper %w", err)
+	}
 
 	if err = mgr.Start(ctx); err != nil {
 		return err
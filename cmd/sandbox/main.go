@@ -1,15 +1,22 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/fatih/color"
+	"k8s.io/client-go/util/exec"
 
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox"
 )
 
 func main() {
 	if err := sandbox.NewSandboxCommand().Execute(); err != nil {
+		// The exit code of the remote command is passed as is.
+		var exitErr exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			os.Exit(exitErr.ExitStatus())
+		}
 		red := color.New(color.FgRed)
 		_, _ = red.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,21 @@
+#!/usr/bin/env bash
+# Vendors the xterm.js assets of the web console, the apiserver embeds and serves them.
+# The list of the assets is kept in sync with internal/apiserver/registry/sandbox/rest/console.go.
+# Set ASSETS_URL to download the npm packages from the mirror.
+set -euo pipefail
+
+ASSETS_URL="${ASSETS_URL:-https://cdn.jsdelivr.net/npm}"
+DEST="$(cd "$(dirname "$0")/.." && pwd)/internal/apiserver/registry/sandbox/rest/console/assets"
+
+assets=(
+  "xterm.css @xterm/xterm@5.5.0/css/xterm.css"
+  "xterm.js @xterm/xterm@5.5.0/lib/xterm.js"
+  "addon-fit.js @xterm/addon-fit@0.10.0/lib/addon-fit.js"
+)
+
+mkdir -p "${DEST}"
+for asset in "${assets[@]}"; do
+  read -r file pkg_path <<<"${asset}"
+  curl -fsSL --retry 3 -o "${DEST}/${file}" "${ASSETS_URL}/${pkg_path}"
+  echo "${file}: sha384-$(openssl dgst -sha384 -binary "${DEST}/${file}" | openssl base64 -A)"
+done
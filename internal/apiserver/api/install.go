@@ -5,7 +5,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 
@@ -43,8 +42,13 @@ func init() {
 func Build(storage *storage.Storage) genericapiserver.APIGroupInfo {
 	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(subresources.GroupName, Scheme, ParameterCodec, Codecs)
 	resources := map[string]rest.Storage{
-		"sandboxes":        storage,
-		"sandboxes/attach": storage.AttachREST(),
+		"sandboxes":            storage,
+		"sandboxes/attach":     storage.AttachREST(),
+		"sandboxes/exec":       storage.ExecREST(),
+		"sandboxes/vnc":        storage.VNCREST(),
+		"sandboxes/recordings": storage.RecordingsREST(),
+		"sandboxes/sessions":   storage.SessionsREST(),
+		"sandboxes/console-ui": storage.ConsoleUIREST(),
 	}
 	apiGroupInfo.VersionedResourcesStorageMap[subv1alpha1.SchemeGroupVersion.Version] = resources
 	return apiGroupInfo
@@ -54,10 +58,11 @@ func Install(
 	sandboxLister corelisters.SandboxLister,
 	server *genericapiserver.GenericAPIServer,
 	client client.GenericClient,
-	serviceAccount types.NamespacedName,
 	restConfig *configrest.Config,
+	recordingDir string,
+	consoleAssetsURL string,
 ) error {
-	sandboxStorage := storage.NewStorage(serviceAccount, sandboxLister, client, restConfig)
+	sandboxStorage := storage.NewStorage(sandboxLister, client, restConfig, recordingDir, consoleAssetsURL)
 	info := Build(sandboxStorage)
 	return server.InstallAPIGroup(&info)
 }
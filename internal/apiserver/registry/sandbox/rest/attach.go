@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -16,44 +18,56 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/proxy"
+	"k8s.io/apiserver/pkg/authentication/user"
 	genericreq "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	configrest "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
 	"k8s.io/kubectl/pkg/scheme"
 	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
 	dvpkubeclient "github.com/deckhouse/virtualization/api/client/kubeclient"
 	dvpsubs "github.com/deckhouse/virtualization/api/subresources/v1alpha2"
 
 	corelisters "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/listers/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
-	sanboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
 	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/registry/sandbox/client"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
-	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/condition"
 	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
 )
 
 var upgradeableMethods = []string{http.MethodGet, http.MethodPost}
 
-func NewAttachREST(serviceAccount types.NamespacedName, sandboxLister corelisters.SandboxLister, client client.GenericClient, restConfig *configrest.Config) *AttachREST {
+// podUpgrader selects the resize protocol, if the client supports it.
+// The consoles of the virtual machines are serial, so they are proxied as is and the resize is not supported.
+var podUpgrader = websocket.Upgrader{
+	Subprotocols: []string{kubeclient.ResizeStreamProtocolName},
+	CheckOrigin:  func(*http.Request) bool { return true },
+}
+
+func NewAttachREST(sandboxLister corelisters.SandboxLister, client client.GenericClient, restConfig *configrest.Config, hub *SessionHub, recordingDir string) *AttachREST {
 	return &AttachREST{
-		serviceAccount: serviceAccount,
-		sandboxLister:  sandboxLister,
-		client:         client,
-		restConfig:     restConfig,
+		sandboxLister: sandboxLister,
+		client:        client,
+		restConfig:    restConfig,
+		hub:           hub,
+		recordingDir:  recordingDir,
 	}
 }
 
+// AttachREST attaches to the console of the sandbox on behalf of the requesting user.
+// The user is required to be allowed the custom attach verb of the subresource.
 type AttachREST struct {
-	serviceAccount types.NamespacedName
-	sandboxLister  corelisters.SandboxLister
-	client         client.GenericClient
-	restConfig     *configrest.Config
+	sandboxLister corelisters.SandboxLister
+	client        client.GenericClient
+	restConfig    *configrest.Config
+	hub           *SessionHub
+	recordingDir  string
 }
 
 var (
@@ -67,93 +81,196 @@ func (r AttachREST) New() runtime.Object {
 
 func (r AttachREST) Destroy() {}
 
-func (r AttachREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+func (r AttachREST) Connect(ctx context.Context, name string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.Attach)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	u, err := requestUser(ctx)
+	if err != nil {
+		return nil, err
+	}
 	namespace := genericreq.NamespaceValue(ctx)
+	if err = authorize(ctx, r.client, u, attachVerb, "attach", namespace, name); err != nil {
+		return nil, err
+	}
+
 	sandbox, err := r.sandboxLister.Sandboxes(namespace).Get(name)
 	if err != nil {
 		return nil, err
 	}
 
-	if c, _ := condition.GetCondition(sanboxcondition.TypeReady, sandbox.Status.Conditions); c.Status != metav1.ConditionTrue {
-		return nil, fmt.Errorf("sandbox %s is not ready", name)
+	sandbox, sandboxType, err := resolveTarget(sandbox, options.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case options.Mode != "" && options.Mode != subv1alpha1.AttachModeDrive && sandboxType != v1alpha1.SandboxTypePod:
+		return nil, apierrors.NewMethodNotSupported(subv1alpha1.Resource("sandboxes/attach"), fmt.Sprintf("mode %s of the %s sandbox", options.Mode, sandboxType))
+	case options.Mode == subv1alpha1.AttachModeObserve && !r.hub.exists(sessionKey(namespace, name, options.Component)):
+		return nil, apierrors.NewNotFound(subv1alpha1.Resource("sessions"), name)
 	}
 
 	if err = secrets.load(); err != nil {
 		return nil, fmt.Errorf("failed to load secrets: %w", err)
 	}
 
+	session := newAttachSession(ctx, namespace, name, sandbox.UID, options.Component, sandboxType, options.Mode)
+	handler, err := r.newHandler(withAttachSession(ctx, session), u, sandbox, sandboxType, responder)
+	if err != nil {
+		return nil, err
+	}
+	return sessionHandler(session, handler), nil
+}
+
+func (r AttachREST) newHandler(ctx context.Context, u user.Info, sandbox *v1alpha1.Sandbox, sandboxType v1alpha1.SandboxType, responder rest.Responder) (http.Handler, error) {
 	nameDepsObj := common.GetFullName(sandbox)
-	switch sandbox.Status.Type {
+	switch sandboxType {
 	case v1alpha1.SandboxTypePod:
-		pod, err := r.client.Kubernetes().CoreV1().Pods(sandbox.Namespace).Get(ctx, nameDepsObj, metav1.GetOptions{})
+		pod, err := r.client.Kubernetes().CoreV1().Pods(common.GetChildNamespace(sandbox)).Get(ctx, nameDepsObj, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
-		remoteLocation := r.getPodLocation(pod)
+		// The client, which may drive the shared session, writes to the container on behalf of the owner of the stream.
+		if attachSessionFrom(ctx).mode != subv1alpha1.AttachModeObserve {
+			if err = authorizePodExec(ctx, r.client, u, pod.Namespace, pod.Name); err != nil {
+				return nil, err
+			}
+		}
+		remoteLocation := getPodExecLocation(r.client, pod, &corev1.PodExecOptions{
+			Command: []string{"/bin/bash"},
+			Stdin:   true,
+			Stdout:  true,
+			TTY:     true,
+		})
 		return r.podHandler(ctx, remoteLocation, responder), nil
 	case v1alpha1.SandboxTypeKubevirtVMI:
 		kubevirtClient, err := r.client.Kubevirt()
 		if err != nil {
 			return nil, err
 		}
-		vmi, err := kubevirtClient.VirtualMachineInstance(sandbox.Namespace).Get(ctx, nameDepsObj, metav1.GetOptions{})
+		vmi, err := kubevirtClient.VirtualMachineInstance(common.GetChildNamespace(sandbox)).Get(ctx, nameDepsObj, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
-		remoteLocation, err := r.getKubevirtVMILocation(vmi)
+		remoteLocation, err := getKubevirtVMILocation(kubevirtClient, vmi, "console")
 		if err != nil {
 			return nil, err
 		}
 
-		return r.proxyHandler(remoteLocation, responder)
+		return newProxyHandler(remoteLocation, u, responder)
 	case v1alpha1.SandboxTypeDVPVM:
 		dvpClient, err := r.client.DVP()
 		if err != nil {
 			return nil, err
 		}
-		vm, err := dvpClient.VirtualMachines(sandbox.Namespace).Get(ctx, nameDepsObj, metav1.GetOptions{})
+		vm, err := dvpClient.VirtualMachines(common.GetChildNamespace(sandbox)).Get(ctx, nameDepsObj, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
-		remoteLocation, err := r.getDVPVMLocation(vm)
+		remoteLocation, err := getDVPVMLocation(r.restConfig, vm, "console")
 		if err != nil {
 			return nil, err
 		}
-		return r.proxyHandler(remoteLocation, responder)
+		return newProxyHandler(remoteLocation, u, responder)
 	default:
-		return nil, fmt.Errorf("unknown sandbox type %s", sandbox.Status.Type)
+		return nil, fmt.Errorf("unknown sandbox type %s", sandboxType)
 	}
 }
 
+// podHandler joins the client to the shared session of the pod, the stream to the container is started by the first client.
+// The clients of kubectl are served with the v5.channel.k8s.io protocol, the others with the plain or the resize protocol.
 func (r AttachREST) podHandler(ctx context.Context, remoteLocation *url.URL, responder rest.Responder) http.Handler {
 	var handler http.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		r.setHeaders(request)
 		if !isWebSocketRequest(request) {
 			responder.Error(apierrors.NewBadRequest("WebSocket upgrade required"))
 			return
 		}
 
-		conn, err := websocket.Upgrade(writer, request, nil, 0, 0)
+		session := attachSessionFrom(ctx)
+		c := &sessionClient{user: session.user, mode: session.mode}
+		if isChannelRequest(request) {
+			streams, err := openChannelStreams(writer, request, true, true, false)
+			if err != nil {
+				slog.Error("Failed to open channel streams", append(session.attrs(), logging.SlogErr(err))...)
+				return
+			}
+			defer func() {
+				_ = streams.conn.Close()
+			}()
+			c.conn = &channelClientConn{streams: streams}
+		} else {
+			conn, err := podUpgrader.Upgrade(writer, request, nil)
+			if err != nil {
+				responder.Error(apierrors.NewInternalError(fmt.Errorf("failed to upgrade to websocket: %w", err)))
+				return
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					slog.Error("Failed to close websocket connection", logging.SlogErr(err))
+				}
+			}()
+			c.conn = &wsClientConn{conn: conn}
+		}
+
+		key := sessionKey(session.namespace, session.name, session.component)
+		shared, started, err := r.hub.join(key, c, func() *sharedSession {
+			return newSharedSession(key, session, r.recordingDir)
+		})
 		if err != nil {
-			responder.Error(apierrors.NewInternalError(fmt.Errorf("failed to upgrade to websocket: %w", err)))
+			c.conn.close(apierrors.NewNotFound(subv1alpha1.Resource("sessions"), session.name))
 			return
 		}
+		session.shared = shared
+		if started {
+			go r.runSharedSession(ctx, shared, remoteLocation)
+		}
+		r.hub.serve(shared, c)
+	})
+	return handler
+}
+
+// runSharedSession streams the shared session to the container until the stream is over or the last client leaves.
+// The stream outlives the request of the client, which started it, and runs on behalf of that client.
+// The clients joining the session later are authorized by the attach verb, the clients, which may drive it,
+// are required to be allowed the exec of the pod as well.
+func (r AttachREST) runSharedSession(ctx context.Context, s *sharedSession, remoteLocation *url.URL) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var (
+		stdin  io.Reader = s
+		stdout io.Writer = s
+	)
+	if s.cast != nil {
+		stdin = &castInputReader{reader: stdin, cast: s.cast}
+		stdout = &castOutputWriter{writer: stdout, cast: s.cast}
 		defer func() {
-			if err := conn.Close(); err != nil {
-				slog.Error("Failed to close websocket connection", logging.SlogErr(err))
+			if err := s.cast.Close(); err != nil {
+				slog.Error("Failed to close session recording", slog.String("recording", s.cast.file.Name()), logging.SlogErr(err))
 			}
 		}()
+	}
 
-		if err := r.spdyStream(ctx, conn, remoteLocation); err != nil {
-			responder.Error(apierrors.NewInternalError(fmt.Errorf("failed to stream to kube-apiserver %s", err)))
-		}
-	})
-	return handler
+	err := r.spdyStream(ctx, stdin, stdout, s, remoteLocation)
+	var exitErr exec.CodeExitError
+	if err != nil && ctx.Err() == nil && !errors.As(err, &exitErr) {
+		slog.Error("Failed to stream to kube-apiserver", slog.String("session", s.id), logging.SlogErr(err))
+	}
+	r.hub.finish(s, err)
 }
 
 func (r AttachREST) kubevirtVMIHandler(ctx context.Context, remoteLocation *url.URL, responder rest.Responder) http.Handler {
 	var handler http.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		r.setHeaders(request)
 		if !isWebSocketRequest(request) {
 			responder.Error(apierrors.NewBadRequest("WebSocket upgrade required"))
 			return
@@ -166,45 +283,42 @@ func (r AttachREST) kubevirtVMIHandler(ctx context.Context, remoteLocation *url.
 		}
 		defer conn.Close()
 
-		if err := r.spdyStream(ctx, conn, remoteLocation); err != nil {
+		if err := r.spdyStream(ctx, &wsStreamReader{conn: conn}, &wsStreamWriter{conn: conn}, nil, remoteLocation); err != nil {
 			responder.Error(apierrors.NewInternalError(fmt.Errorf("failed to stream to kube-apiserver %s", err)))
 		}
 	})
 	return handler
 }
 
-func (r AttachREST) proxyHandler(remoteLocation *url.URL, responder rest.Responder) (http.Handler, error) {
+func newProxyHandler(remoteLocation *url.URL, u user.Info, responder rest.Responder) (http.Handler, error) {
 	transport, err := getTransportWithClusterCA(secrets.ca)
 	if err != nil {
 		return nil, err
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		r.setHeaders(req)
+		setHeaders(req, u)
 		handler := proxy.NewUpgradeAwareHandler(remoteLocation, transport, false, true, proxy.NewErrorResponder(responder))
 		handler.ServeHTTP(w, req)
 	}), nil
 }
 
-func (r AttachREST) setHeaders(request *http.Request) {
-	request.Header.Set("Authorization", "Bearer "+secrets.token)
-	request.Header.Set("X-Remote-User", fmt.Sprintf("system:serviceaccount:%s:%s", r.serviceAccount.Namespace, r.serviceAccount.Name))
-	request.Header.Set("X-Remote-Group", "system:serviceaccounts")
-}
-
-func (r AttachREST) spdyStream(ctx context.Context, wsConn *websocket.Conn, remoteLocation *url.URL) error {
-	executor, err := remotecommand.NewSPDYExecutor(r.restConfig, "POST", remoteLocation)
+func (r AttachREST) spdyStream(ctx context.Context, stdin io.Reader, stdout io.Writer, sizeQueue remotecommand.TerminalSizeQueue, remoteLocation *url.URL) error {
+	u, err := requestUser(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create SPDY executor: %v", err)
+		return err
 	}
-
-	streamOpts := remotecommand.StreamOptions{
-		Stdin:  &wsStreamReader{conn: wsConn},
-		Stdout: &wsStreamWriter{conn: wsConn},
-		Stderr: &wsStreamWriter{conn: wsConn},
-		Tty:    true,
+	executor, err := remotecommand.NewSPDYExecutor(impersonatedConfig(r.restConfig, u), "POST", remoteLocation)
+	if err != nil {
+		return fmt.Errorf("failed to create SPDY executor: %v", err)
 	}
 
-	return executor.StreamWithContext(ctx, streamOpts)
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		Stderr:            stdout,
+		Tty:               true,
+		TerminalSizeQueue: sizeQueue,
+	})
 }
 func (r AttachREST) NewConnectOptions() (runtime.Object, bool, string) {
 	return &subv1alpha1.Attach{}, false, ""
@@ -214,55 +328,46 @@ func (r AttachREST) ConnectMethods() []string {
 	return upgradeableMethods
 }
 
-func (r AttachREST) getPodLocation(pod *corev1.Pod) *url.URL {
+// getPodExecLocation returns the location of the command execution in the default container of the pod.
+func getPodExecLocation(c client.GenericClient, pod *corev1.Pod, opts *corev1.PodExecOptions) *url.URL {
 	const defaultContainerAnnotationName = "kubectl.kubernetes.io/default-container"
-	containerName := pod.Spec.Containers[0].Name
+	opts.Container = pod.Spec.Containers[0].Name
 	for _, container := range pod.Spec.Containers {
 		if pod.Annotations[defaultContainerAnnotationName] == "true" {
-			containerName = container.Name
+			opts.Container = container.Name
 			break
 		}
 	}
-	return r.client.Kubernetes().CoreV1().RESTClient().
+	return c.Kubernetes().CoreV1().RESTClient().
 		Post().
 		Resource("pods").
 		Namespace(pod.Namespace).
 		Name(pod.Name).
 		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Container: containerName,
-			Command:   []string{"/bin/bash"},
-			Stdin:     true,
-			Stdout:    true,
-			TTY:       true,
-		}, scheme.ParameterCodec).
+		VersionedParams(opts, scheme.ParameterCodec).
 		URL()
 }
 
-func (r AttachREST) getKubevirtVMILocation(pod *virtv1.VirtualMachineInstance) (*url.URL, error) {
-	const subresourceURLTpl = "/apis/subresources.kubevirt.io/v1/namespaces/%s/virtualmachineinstances/%s/console"
+func getKubevirtVMILocation(kubevirt kubecli.KubevirtClient, vmi *virtv1.VirtualMachineInstance, subresource string) (*url.URL, error) {
+	const subresourceURLTpl = "/apis/subresources.kubevirt.io/v1/namespaces/%s/virtualmachineinstances/%s/%s"
 
-	kubevirt, err := r.client.Kubevirt()
-	if err != nil {
-		return nil, err
-	}
 	return kubevirt.RestClient().
 		Post().
-		AbsPath(fmt.Sprintf(subresourceURLTpl, pod.Namespace, pod.Name)).
+		AbsPath(fmt.Sprintf(subresourceURLTpl, vmi.Namespace, vmi.Name, subresource)).
 		URL(), nil
 }
 
-func (r AttachREST) getDVPVMLocation(vm *dvpcorev1alpha2.VirtualMachine) (*url.URL, error) {
-	const vmPathTmpl = "/apis/subresources.virtualization.deckhouse.io/v1alpha2/namespaces/%s/virtualmachines/%s/console"
+func getDVPVMLocation(restConfig *configrest.Config, vm *dvpcorev1alpha2.VirtualMachine, subresource string) (*url.URL, error) {
+	const vmPathTmpl = "/apis/subresources.virtualization.deckhouse.io/v1alpha2/namespaces/%s/virtualmachines/%s/%s"
 
-	restClient, err := restClientForDVPVM(r.restConfig)
+	restClient, err := restClientForDVPVM(restConfig)
 	if err != nil {
 		return nil, err
 	}
 
 	return restClient.
 		Post().
-		AbsPath(fmt.Sprintf(vmPathTmpl, vm.Namespace, vm.Name)).
+		AbsPath(fmt.Sprintf(vmPathTmpl, vm.Namespace, vm.Name, subresource)).
 		URL(), nil
 }
 
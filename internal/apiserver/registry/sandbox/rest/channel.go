@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream/wsstream"
+	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const channelStatusWriteTimeout = 10 * time.Second
+
+// isChannelRequest reports whether the client supports the v5.channel.k8s.io protocol of kubectl.
+func isChannelRequest(req *http.Request) bool {
+	return wsstream.IsWebSocketRequestWithStreamCloseProtocol(req)
+}
+
+// channelStreams are the streams of the v5.channel.k8s.io protocol:
+// stdin, stdout, stderr, the error channel with the exit status and the resize channel.
+type channelStreams struct {
+	conn   *wsstream.Conn
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	status io.Writer
+	resize io.Reader
+}
+
+func openChannelStreams(w http.ResponseWriter, req *http.Request, stdin, stdout, stderr bool) (*channelStreams, error) {
+	channels := make([]wsstream.ChannelType, 5)
+	channels[remotecommandconsts.StreamStdIn] = channelType(stdin, wsstream.ReadChannel)
+	channels[remotecommandconsts.StreamStdOut] = channelType(stdout, wsstream.WriteChannel)
+	channels[remotecommandconsts.StreamStdErr] = channelType(stderr, wsstream.WriteChannel)
+	channels[remotecommandconsts.StreamErr] = wsstream.WriteChannel
+	channels[remotecommandconsts.StreamResize] = wsstream.ReadChannel
+
+	conn := wsstream.NewConn(map[string]wsstream.ChannelProtocolConfig{
+		remotecommandconsts.StreamProtocolV5Name: {
+			Binary:   true,
+			Channels: channels,
+		},
+	})
+	_, streams, err := conn.Open(w, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &channelStreams{
+		conn:   conn,
+		status: streams[remotecommandconsts.StreamErr],
+		resize: streams[remotecommandconsts.StreamResize],
+	}
+	if stdin {
+		s.stdin = streams[remotecommandconsts.StreamStdIn]
+	}
+	if stdout {
+		s.stdout = streams[remotecommandconsts.StreamStdOut]
+	}
+	if stderr {
+		s.stderr = streams[remotecommandconsts.StreamStdErr]
+	}
+
+	// The empty message to the lowest writable channel notifies the client, that the streams are ready.
+	ready := s.status
+	switch {
+	case s.stdout != nil:
+		ready = s.stdout
+	case s.stderr != nil:
+		ready = s.stderr
+	}
+	if _, err = ready.Write([]byte{}); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to open the streams: %w", err)
+	}
+	return s, nil
+}
+
+func channelType(enabled bool, t wsstream.ChannelType) wsstream.ChannelType {
+	if enabled {
+		return t
+	}
+	return wsstream.IgnoreChannel
+}
+
+// sizeQueue returns the queue of the terminal sizes sent by the client to the resize channel.
+// The queue is closed, when the connection is closed or done is closed.
+func (s *channelStreams) sizeQueue(done <-chan struct{}) remotecommand.TerminalSizeQueue {
+	sizes := make(chan remotecommand.TerminalSize)
+	go func() {
+		defer close(sizes)
+		decoder := json.NewDecoder(s.resize)
+		for {
+			var size remotecommand.TerminalSize
+			if err := decoder.Decode(&size); err != nil {
+				return
+			}
+			select {
+			case sizes <- size:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return channelSizeQueue(sizes)
+}
+
+type channelSizeQueue chan remotecommand.TerminalSize
+
+func (q channelSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// writeStatus writes the result of the stream to the error channel, the exit code of the command is passed as the cause.
+func (s *channelStreams) writeStatus(err error) error {
+	status := metav1.Status{Status: metav1.StatusSuccess}
+
+	var (
+		statusErr *apierrors.StatusError
+		exitErr   exec.CodeExitError
+	)
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr) && exitErr.Exited():
+		status = metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  remotecommandconsts.NonZeroExitCodeReason,
+			Message: fmt.Sprintf("command terminated with non-zero exit code: %v", exitErr),
+			Details: &metav1.StatusDetails{
+				Causes: []metav1.StatusCause{
+					{
+						Type:    remotecommandconsts.ExitCodeCauseType,
+						Message: fmt.Sprintf("%d", exitErr.ExitStatus()),
+					},
+				},
+			},
+		}
+	case errors.As(err, &statusErr):
+		status = statusErr.Status()
+	default:
+		status = apierrors.NewInternalError(err).Status()
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	s.conn.SetWriteDeadline(channelStatusWriteTimeout)
+	_, err = s.status.Write(data)
+	return err
+}
+
+// channelClientConn is the client of the shared session connected with the v5.channel.k8s.io protocol.
+type channelClientConn struct {
+	streams *channelStreams
+}
+
+func (c *channelClientConn) serve(input func([]byte) bool, resize func(remotecommand.TerminalSize)) {
+	queue := c.streams.sizeQueue(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for size := queue.Next(); size != nil; size = queue.Next() {
+			resize(*size)
+		}
+	}()
+
+	// The client may close stdin with the close signal, it stays in the session until it is disconnected.
+	// The resize channel is closed only when the connection is closed.
+	if c.streams.stdin != nil {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := c.streams.stdin.Read(buf)
+			if n > 0 && !input(append([]byte(nil), buf[:n]...)) {
+				return
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	<-done
+}
+
+func (c *channelClientConn) write(p []byte) error {
+	c.streams.conn.SetWriteDeadline(clientWriteTimeout)
+	_, err := c.streams.stdout.Write(p)
+	return err
+}
+
+func (c *channelClientConn) close(err error) {
+	_ = c.streams.writeStatus(err)
+	_ = c.streams.conn.Close()
+}
+
+func (c *channelClientConn) drop() {
+	_ = c.streams.conn.Close()
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sanboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/condition"
+)
+
+// resolveTarget returns the sandbox to connect to and its type.
+// For the multi-component sandbox it is the copy of the sandbox with the ready component.
+func resolveTarget(sandbox *v1alpha1.Sandbox, component string) (*v1alpha1.Sandbox, v1alpha1.SandboxType, error) {
+	if sandbox.Status.Type != v1alpha1.SandboxTypeComposite {
+		if component != "" {
+			return nil, "", fmt.Errorf("sandbox %s has no components", sandbox.Name)
+		}
+		if c, _ := condition.GetCondition(sanboxcondition.TypeReady, sandbox.Status.Conditions); c.Status != metav1.ConditionTrue {
+			return nil, "", fmt.Errorf("sandbox %s is not ready", sandbox.Name)
+		}
+		return sandbox, sandbox.Status.Type, nil
+	}
+
+	var names []string
+	for _, status := range sandbox.Status.Components {
+		if status.Name != component {
+			names = append(names, status.Name)
+			continue
+		}
+		if status.Status != metav1.ConditionTrue {
+			return nil, "", fmt.Errorf("component %s of sandbox %s is not ready", component, sandbox.Name)
+		}
+		return common.WithComponent(sandbox, component), status.Type, nil
+	}
+	if component == "" {
+		return nil, "", fmt.Errorf("component must be specified for sandbox %s, one of: %s", sandbox.Name, strings.Join(names, ", "))
+	}
+	return nil, "", apierrors.NewNotFound(v1alpha1.Resource("components"), component)
+}
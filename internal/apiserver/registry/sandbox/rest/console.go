@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+// ConsoleUIAnyName is the name of the sandbox in the console path, when no sandbox is selected.
+const ConsoleUIAnyName = "-"
+
+// consoleAssetsPath is the subpath of the console, the vendored assets are served under it.
+const consoleAssetsPath = "assets"
+
+//go:embed console/index.html
+var consoleUIPage string
+
+// consoleUIAssets are the xterm.js assets vendored by hack/vendor-console-assets.sh.
+//
+//go:embed console/assets
+var consoleUIAssets embed.FS
+
+var consoleUITemplate = template.Must(template.New("console").Parse(consoleUIPage))
+
+// consoleAsset is the asset of the page, the file is vendored from the npm package of the pinned version.
+// The list is kept in sync with hack/vendor-console-assets.sh.
+type consoleAsset struct {
+	file    string
+	pkgPath string
+	style   bool
+}
+
+var consoleAssets = []consoleAsset{
+	{file: "xterm.css", pkgPath: "@xterm/xterm@5.5.0/css/xterm.css", style: true},
+	{file: "xterm.js", pkgPath: "@xterm/xterm@5.5.0/lib/xterm.js"},
+	{file: "addon-fit.js", pkgPath: "@xterm/addon-fit@0.10.0/lib/addon-fit.js"},
+}
+
+type consoleAssetLink struct {
+	URL       string
+	Integrity string
+}
+
+func NewConsoleUIREST(assetsURL string) *ConsoleUIREST {
+	r := &ConsoleUIREST{
+		assetsURL: strings.TrimSuffix(assetsURL, "/"),
+		assets:    make(map[string][]byte, len(consoleAssets)),
+		integrity: make(map[string]string, len(consoleAssets)),
+	}
+	for _, asset := range consoleAssets {
+		content, err := consoleUIAssets.ReadFile(path.Join("console", consoleAssetsPath, asset.file))
+		if err != nil {
+			slog.Error("The asset of the web console is not vendored, run hack/vendor-console-assets.sh", slog.String("asset", asset.file), logging.SlogErr(err))
+			continue
+		}
+		sum := sha512.Sum384(content)
+		r.assets[asset.file] = content
+		r.integrity[asset.file] = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+	return r
+}
+
+// ConsoleUIREST serves the web terminal of the sandboxes.
+// The page lists the sandboxes and attaches to them with the same credentials, so the API authorization applies.
+// The sandbox is not required to exist, the page allows choosing another one.
+// The xterm.js assets are vendored and served under the subpath of the console, the assets URL overrides them,
+// then the browser checks the loaded assets against the vendored ones with the subresource integrity.
+type ConsoleUIREST struct {
+	assetsURL string
+	assets    map[string][]byte
+	integrity map[string]string
+}
+
+var (
+	_ rest.Storage   = &ConsoleUIREST{}
+	_ rest.Connecter = &ConsoleUIREST{}
+)
+
+func (r ConsoleUIREST) New() runtime.Object {
+	return &subv1alpha1.ConsoleUI{}
+}
+
+func (r ConsoleUIREST) Destroy() {}
+
+func (r ConsoleUIREST) Connect(ctx context.Context, name string, opts runtime.Object, _ rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.ConsoleUI)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	if subpath := strings.Trim(options.Path, "/"); subpath != "" {
+		return r.assetHandler(name, subpath)
+	}
+
+	namespace := genericreq.NamespaceValue(ctx)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var page bytes.Buffer
+		err := consoleUITemplate.Execute(&page, r.pageData(req, namespace, name, options.Component))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to render the console: %s", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(page.Bytes())
+	}), nil
+}
+
+// pageData returns the data of the page, the vendored assets are linked relative to the path of the page,
+// so they are served with the same prefix of the kube-apiserver or kubectl proxy.
+func (r ConsoleUIREST) pageData(req *http.Request, namespace, name, component string) any {
+	base := path.Base(req.URL.Path) + "/" + consoleAssetsPath
+	if strings.HasSuffix(req.URL.Path, "/") {
+		base = consoleAssetsPath
+	}
+
+	var styles, scripts []consoleAssetLink
+	for _, asset := range consoleAssets {
+		link := consoleAssetLink{
+			URL:       base + "/" + asset.file,
+			Integrity: r.integrity[asset.file],
+		}
+		if r.assetsURL != "" {
+			link.URL = r.assetsURL + "/" + asset.pkgPath
+		}
+		if asset.style {
+			styles = append(styles, link)
+		} else {
+			scripts = append(scripts, link)
+		}
+	}
+
+	return struct {
+		Namespace string
+		Name      string
+		Component string
+		Styles    []consoleAssetLink
+		Scripts   []consoleAssetLink
+	}{
+		Namespace: namespace,
+		Name:      name,
+		Component: component,
+		Styles:    styles,
+		Scripts:   scripts,
+	}
+}
+
+func (r ConsoleUIREST) assetHandler(name, subpath string) (http.Handler, error) {
+	file, ok := strings.CutPrefix(subpath, consoleAssetsPath+"/")
+	content, vendored := r.assets[file]
+	if !ok || !vendored {
+		return nil, apierrors.NewNotFound(subv1alpha1.Resource("sandboxes/console-ui"), name+"/"+subpath)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(file)))
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	}), nil
+}
+
+func (r ConsoleUIREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &subv1alpha1.ConsoleUI{}, true, "path"
+}
+
+func (r ConsoleUIREST) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	configrest "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	corelisters "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/listers/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/registry/sandbox/client"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+func NewExecREST(sandboxLister corelisters.SandboxLister, client client.GenericClient, restConfig *configrest.Config) *ExecREST {
+	return &ExecREST{
+		sandboxLister: sandboxLister,
+		client:        client,
+		restConfig:    restConfig,
+	}
+}
+
+// ExecREST executes the command in the pod sandbox on behalf of the requesting user.
+// Only the v5.channel.k8s.io protocol is served, so the exit status of the command is passed to the client.
+type ExecREST struct {
+	sandboxLister corelisters.SandboxLister
+	client        client.GenericClient
+	restConfig    *configrest.Config
+}
+
+var (
+	_ rest.Storage   = &ExecREST{}
+	_ rest.Connecter = &ExecREST{}
+)
+
+func (r ExecREST) New() runtime.Object {
+	return &subv1alpha1.Exec{}
+}
+
+func (r ExecREST) Destroy() {}
+
+func (r ExecREST) Connect(ctx context.Context, name string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.Exec)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+	if len(options.Command) == 0 {
+		return nil, apierrors.NewBadRequest("command is required")
+	}
+	if !options.Stdin && !options.Stdout && !options.Stderr {
+		return nil, apierrors.NewBadRequest("at least one of stdin, stdout or stderr is required")
+	}
+
+	u, err := requestUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespace := genericreq.NamespaceValue(ctx)
+	// The console and the commands give the same access to the sandbox as the attachment.
+	if err = authorize(ctx, r.client, u, attachVerb, "attach", namespace, name); err != nil {
+		return nil, err
+	}
+	sandbox, err := r.sandboxLister.Sandboxes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sandbox, sandboxType, err := resolveTarget(sandbox, options.Component)
+	if err != nil {
+		return nil, err
+	}
+	if sandboxType != v1alpha1.SandboxTypePod {
+		return nil, apierrors.NewMethodNotSupported(subv1alpha1.Resource("sandboxes/exec"), fmt.Sprintf("exec in the %s sandbox", sandboxType))
+	}
+
+	pod, err := r.client.Kubernetes().CoreV1().Pods(common.GetChildNamespace(sandbox)).Get(ctx, common.GetFullName(sandbox), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	remoteLocation := getPodExecLocation(r.client, pod, &corev1.PodExecOptions{
+		Command: options.Command,
+		Stdin:   options.Stdin,
+		Stdout:  options.Stdout,
+		Stderr:  options.Stderr && !options.TTY,
+		TTY:     options.TTY,
+	})
+
+	session := newAttachSession(ctx, namespace, name, sandbox.UID, options.Component, sandboxType, "")
+	session.command = options.Command
+	return sessionHandler(session, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !isChannelRequest(request) {
+			responder.Error(apierrors.NewBadRequest(fmt.Sprintf("WebSocket upgrade with the %s protocol required", remotecommandconsts.StreamProtocolV5Name)))
+			return
+		}
+
+		streams, err := openChannelStreams(writer, request, options.Stdin, options.Stdout, options.Stderr && !options.TTY)
+		if err != nil {
+			slog.Error("Failed to open channel streams", append(session.attrs(), logging.SlogErr(err))...)
+			return
+		}
+		defer func() {
+			_ = streams.conn.Close()
+		}()
+
+		streamOpts := remotecommand.StreamOptions{
+			Tty: options.TTY,
+		}
+		if streams.stdin != nil {
+			streamOpts.Stdin = streams.stdin
+		}
+		if streams.stdout != nil {
+			streamOpts.Stdout = streams.stdout
+		}
+		if streams.stderr != nil {
+			streamOpts.Stderr = streams.stderr
+		}
+		if options.TTY {
+			streamOpts.TerminalSizeQueue = streams.sizeQueue(request.Context().Done())
+		}
+
+		executor, err := remotecommand.NewSPDYExecutor(impersonatedConfig(r.restConfig, u), "POST", remoteLocation)
+		if err == nil {
+			err = executor.StreamWithContext(request.Context(), streamOpts)
+		}
+		if err = streams.writeStatus(err); err != nil {
+			slog.Error("Failed to write exec status", append(session.attrs(), logging.SlogErr(err))...)
+		}
+	})), nil
+}
+
+func (r ExecREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &subv1alpha1.Exec{}, false, ""
+}
+
+func (r ExecREST) ConnectMethods() []string {
+	return upgradeableMethods
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	configrest "k8s.io/client-go/rest"
+
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/registry/sandbox/client"
+)
+
+// attachVerb is the custom verb of the sandboxes/attach subresource.
+// It is required in addition to the access to the subresource, so the attachment is granted explicitly.
+// The vnc and exec subresources give the same access to the sandbox, so they require it as well:
+//
+//	apiGroups: ["subresources.sandbox.io"]
+//	resources: ["sandboxes/attach"]
+//	verbs: ["attach"]
+const attachVerb = "attach"
+
+// requestUser returns the user of the request, the backends are accessed on behalf of it.
+func requestUser(ctx context.Context) (user.Info, error) {
+	u, ok := genericreq.UserFrom(ctx)
+	if !ok || u.GetName() == "" {
+		return nil, apierrors.NewUnauthorized("the user of the request is unknown")
+	}
+	return u, nil
+}
+
+// authorize reviews the access of the user to the subresource of the sandbox with the verb.
+func authorize(ctx context.Context, c client.GenericClient, u user.Info, verb, subresource, namespace, name string) error {
+	return review(ctx, c, u, subv1alpha1.Resource("sandboxes/"+subresource), &authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        verb,
+		Group:       subv1alpha1.SchemeGroupVersion.Group,
+		Version:     subv1alpha1.SchemeGroupVersion.Version,
+		Resource:    "sandboxes",
+		Subresource: subresource,
+		Name:        name,
+	})
+}
+
+// authorizePodExec reviews the access of the user to the exec of the pod.
+// The shared stream to the container runs on behalf of the client, which started it,
+// so the clients driving it later are required to be allowed to open the stream themselves.
+func authorizePodExec(ctx context.Context, c client.GenericClient, u user.Info, namespace, name string) error {
+	return review(ctx, c, u, corev1.Resource("pods/exec"), &authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        "create",
+		Version:     corev1.SchemeGroupVersion.Version,
+		Resource:    "pods",
+		Subresource: "exec",
+		Name:        name,
+	})
+}
+
+func review(ctx context.Context, c client.GenericClient, u user.Info, resource schema.GroupResource, attributes *authorizationv1.ResourceAttributes) error {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               u.GetName(),
+			Groups:             u.GetGroups(),
+			UID:                u.GetUID(),
+			ResourceAttributes: attributes,
+		},
+	}
+	if extra := u.GetExtra(); len(extra) > 0 {
+		review.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(extra))
+		for k, v := range extra {
+			review.Spec.Extra[k] = v
+		}
+	}
+
+	review, err := c.Kubernetes().AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("failed to review the access: %w", err))
+	}
+	if !review.Status.Allowed {
+		reason := review.Status.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("user %q cannot %s %s in the namespace %q", u.GetName(), attributes.Verb, resource, attributes.Namespace)
+		}
+		return apierrors.NewForbidden(resource, attributes.Name, errors.New(reason))
+	}
+	return nil
+}
+
+// setHeaders authenticates the proxied request as the apiserver, which impersonates the requesting user.
+// The headers of the incoming request identifying the user are dropped, only the impersonation headers are trusted.
+func setHeaders(request *http.Request, u user.Info) {
+	for key := range request.Header {
+		if strings.HasPrefix(key, "X-Remote-") || strings.HasPrefix(key, "Impersonate-") {
+			request.Header.Del(key)
+		}
+	}
+	request.Header.Set("Authorization", "Bearer "+secrets.token)
+	request.Header.Set(authenticationv1.ImpersonateUserHeader, u.GetName())
+	if uid := u.GetUID(); uid != "" {
+		request.Header.Set(authenticationv1.ImpersonateUIDHeader, uid)
+	}
+	for _, group := range u.GetGroups() {
+		request.Header.Add(authenticationv1.ImpersonateGroupHeader, group)
+	}
+	for key, values := range u.GetExtra() {
+		for _, value := range values {
+			request.Header.Add(authenticationv1.ImpersonateUserExtraHeaderPrefix+url.PathEscape(key), value)
+		}
+	}
+}
+
+// impersonatedConfig returns the copy of the config, which impersonates the user.
+func impersonatedConfig(config *configrest.Config, u user.Info) *configrest.Config {
+	config = configrest.CopyConfig(config)
+	config.Impersonate = configrest.ImpersonationConfig{
+		UserName: u.GetName(),
+		UID:      u.GetUID(),
+		Groups:   u.GetGroups(),
+		Extra:    u.GetExtra(),
+	}
+	return config
+}
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const metricsNamespace = "sandbox"
+
+var (
+	attachSessionsActive = metrics.NewGaugeVec(&metrics.GaugeOpts{
+		Namespace:      metricsNamespace,
+		Name:           "attach_sessions_active",
+		Help:           "Number of the active attach sessions.",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"type"})
+	attachSessionDuration = metrics.NewHistogramVec(&metrics.HistogramOpts{
+		Namespace:      metricsNamespace,
+		Name:           "attach_session_duration_seconds",
+		Help:           "Duration of the attach sessions.",
+		Buckets:        []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200, 14400},
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"type"})
+	attachBytes = metrics.NewCounterVec(&metrics.CounterOpts{
+		Namespace:      metricsNamespace,
+		Name:           "attach_bytes_total",
+		Help:           "Total number of bytes transferred through the attach sessions, `in` is received from the clients.",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"type", "direction"})
+)
+
+func init() {
+	legacyregistry.MustRegister(attachSessionsActive, attachSessionDuration, attachBytes)
+}
+
+type meteredResponseWriter struct {
+	http.ResponseWriter
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+}
+
+func (w *meteredResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer %T does not support hijacking", w.ResponseWriter)
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	metered := &meteredConn{Conn: conn, writer: w}
+
+	// The websocket servers read and write through the returned buffers, so they are replaced by the buffers of
+	// the metered connection. The bytes, which the server has already read ahead, are read first.
+	buffered, err := rw.Reader.Peek(rw.Reader.Buffered())
+	if err != nil {
+		return nil, nil, err
+	}
+	w.bytesIn.Add(int64(len(buffered)))
+	if err = rw.Writer.Flush(); err != nil {
+		return nil, nil, err
+	}
+	reader := io.MultiReader(bytes.NewReader(bytes.Clone(buffered)), metered)
+	return metered, bufio.NewReadWriter(bufio.NewReaderSize(reader, rw.Reader.Size()), bufio.NewWriterSize(metered, rw.Writer.Size())), nil
+}
+
+func (w *meteredResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type meteredConn struct {
+	net.Conn
+	writer *meteredResponseWriter
+}
+
+func (c *meteredConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.writer.bytesIn.Add(int64(n))
+	return n, err
+}
+
+func (c *meteredConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.writer.bytesOut.Add(int64(n))
+	return n, err
+}
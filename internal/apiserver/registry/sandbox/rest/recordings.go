@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	corelisters "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/listers/core/v1alpha1"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+func NewRecordingsREST(sandboxLister corelisters.SandboxLister, recordingDir string) *RecordingsREST {
+	return &RecordingsREST{
+		sandboxLister: sandboxLister,
+		recordingDir:  recordingDir,
+	}
+}
+
+// RecordingsREST lists and downloads the recordings of the attach sessions.
+// The recordings are kept in the directory after the sandbox deletion, but only the recordings of the existing
+// sandbox are served: they are found by its UID, so the later sandbox of the same name does not expose them.
+type RecordingsREST struct {
+	sandboxLister corelisters.SandboxLister
+	recordingDir  string
+}
+
+var (
+	_ rest.Storage   = &RecordingsREST{}
+	_ rest.Connecter = &RecordingsREST{}
+)
+
+func (r RecordingsREST) New() runtime.Object {
+	return &subv1alpha1.Recordings{}
+}
+
+func (r RecordingsREST) Destroy() {}
+
+func (r RecordingsREST) Connect(ctx context.Context, name string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.Recordings)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+	if r.recordingDir == "" {
+		return nil, apierrors.NewServiceUnavailable("session recording is disabled")
+	}
+
+	namespace := genericreq.NamespaceValue(ctx)
+	sandbox, err := r.sandboxLister.Sandboxes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(getRecordingPath(r.recordingDir, namespace, name, sandbox.UID, ""))
+
+	if options.Session == "" {
+		list, err := listRecordings(dir)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		return http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			responder.Object(http.StatusOK, list)
+		}), nil
+	}
+
+	if options.Session != filepath.Base(options.Session) || strings.HasPrefix(options.Session, ".") {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid session %q", options.Session))
+	}
+	path := getRecordingPath(r.recordingDir, namespace, name, sandbox.UID, options.Session)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, apierrors.NewNotFound(subv1alpha1.Resource("recordings"), options.Session)
+		}
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		file, err := os.Open(path)
+		if err != nil {
+			responder.Error(apierrors.NewInternalError(err))
+			return
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", "application/x-asciicast")
+		w.WriteHeader(http.StatusOK)
+		if _, err = io.Copy(w, file); err != nil {
+			slog.Error("Failed to send session recording", slog.String("recording", path), logging.SlogErr(err))
+		}
+	}), nil
+}
+
+func (r RecordingsREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &subv1alpha1.Recordings{}, false, ""
+}
+
+func (r RecordingsREST) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+func listRecordings(dir string) (*subv1alpha1.SessionRecordingList, error) {
+	list := &subv1alpha1.SessionRecordingList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SessionRecordingList",
+			APIVersion: subv1alpha1.SchemeGroupVersion.String(),
+		},
+		Items: []subv1alpha1.SessionRecording{},
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return list, nil
+		}
+		return nil, fmt.Errorf("failed to read recordings: %w", err)
+	}
+
+	for _, entry := range entries {
+		session, ok := strings.CutSuffix(entry.Name(), castFileExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		recording, err := readRecording(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			slog.Warn("Skipping invalid session recording", slog.String("recording", entry.Name()), logging.SlogErr(err))
+			continue
+		}
+		recording.Session = session
+		list.Items = append(list.Items, recording)
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].StartTime.Before(&list.Items[j].StartTime)
+	})
+	return list, nil
+}
+
+func readRecording(path string) (subv1alpha1.SessionRecording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return subv1alpha1.SessionRecording{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return subv1alpha1.SessionRecording{}, err
+	}
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil {
+		return subv1alpha1.SessionRecording{}, fmt.Errorf("failed to read header: %w", err)
+	}
+	var header castHeader
+	if err = json.Unmarshal(line, &header); err != nil {
+		return subv1alpha1.SessionRecording{}, fmt.Errorf("failed to decode header: %w", err)
+	}
+
+	return subv1alpha1.SessionRecording{
+		User:      header.User,
+		Component: header.Component,
+		StartTime: metav1.NewTime(time.Unix(header.Timestamp, 0)),
+		EndTime:   metav1.NewTime(info.ModTime()),
+		Size:      info.Size(),
+	}, nil
+}
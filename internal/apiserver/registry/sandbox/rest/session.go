@@ -0,0 +1,262 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+const (
+	castFileExt = ".cast"
+	// castWidth and castHeight are the size of the terminal in the header, if the size is not known before the stream.
+	castWidth  = 80
+	castHeight = 24
+)
+
+// attachSession is the attach session of the user, it is audited.
+// The session of the pod sandbox joins the shared session, which is optionally recorded.
+type attachSession struct {
+	id          string
+	user        string
+	groups      []string
+	namespace   string
+	name        string
+	uid         types.UID
+	component   string
+	sandboxType v1alpha1.SandboxType
+	mode        subv1alpha1.AttachMode
+	command     []string
+	start       time.Time
+	shared      *sharedSession
+}
+
+type attachSessionKey struct{}
+
+func newAttachSession(ctx context.Context, namespace, name string, uid types.UID, component string, sandboxType v1alpha1.SandboxType, mode subv1alpha1.AttachMode) *attachSession {
+	if mode == "" {
+		mode = subv1alpha1.AttachModeDrive
+	}
+	s := &attachSession{
+		id:          fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405Z"), utilrand.String(5)),
+		namespace:   namespace,
+		name:        name,
+		uid:         uid,
+		component:   component,
+		sandboxType: sandboxType,
+		mode:        mode,
+	}
+	if user, ok := genericreq.UserFrom(ctx); ok {
+		s.user = user.GetName()
+		s.groups = user.GetGroups()
+	}
+	return s
+}
+
+func withAttachSession(ctx context.Context, s *attachSession) context.Context {
+	return context.WithValue(ctx, attachSessionKey{}, s)
+}
+
+func attachSessionFrom(ctx context.Context) *attachSession {
+	s, _ := ctx.Value(attachSessionKey{}).(*attachSession)
+	return s
+}
+
+func (s *attachSession) attrs() []any {
+	return []any{
+		slog.String("session", s.id),
+		slog.String("user", s.user),
+		slog.Any("groups", s.groups),
+		slog.String("namespace", s.namespace),
+		slog.String("sandbox", s.name),
+		slog.String("component", s.component),
+		slog.String("type", string(s.sandboxType)),
+		slog.String("mode", string(s.mode)),
+		slog.Any("command", s.command),
+	}
+}
+
+// sessionHandler emits the audit records of the session.
+func sessionHandler(s *attachSession, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.start = time.Now()
+
+		labels := []string{string(s.sandboxType)}
+		attachSessionsActive.WithLabelValues(labels...).Inc()
+		slog.Info("Attach session started", append(s.attrs(), slog.Time("start", s.start))...)
+
+		mw := &meteredResponseWriter{ResponseWriter: w}
+		defer func() {
+			end := time.Now()
+			attachSessionsActive.WithLabelValues(labels...).Dec()
+			attachSessionDuration.WithLabelValues(labels...).Observe(end.Sub(s.start).Seconds())
+			attachBytes.WithLabelValues(string(s.sandboxType), "in").Add(float64(mw.bytesIn.Load()))
+			attachBytes.WithLabelValues(string(s.sandboxType), "out").Add(float64(mw.bytesOut.Load()))
+
+			attrs := append(s.attrs(),
+				slog.Time("start", s.start),
+				slog.Time("end", end),
+				slog.Int64("bytesIn", mw.bytesIn.Load()),
+				slog.Int64("bytesOut", mw.bytesOut.Load()),
+			)
+			if s.shared != nil {
+				attrs = append(attrs, slog.String("sharedSession", s.shared.id))
+				if s.shared.cast != nil {
+					attrs = append(attrs, slog.String("recording", s.shared.cast.file.Name()))
+				}
+			}
+			slog.Info("Attach session finished", attrs...)
+		}()
+
+		handler.ServeHTTP(mw, req)
+	})
+}
+
+// getRecordingPath returns the path of the recording, the recordings are kept by the UID of the sandbox,
+// so the later sandbox of the same name does not expose the sessions of the deleted one.
+func getRecordingPath(dir, namespace, name string, uid types.UID, session string) string {
+	return filepath.Join(dir, namespace, name, string(uid), session+castFileExt)
+}
+
+// castHeader is the header of the asciinema v2 recording, user and component are the extra fields.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	User      string            `json:"user,omitempty"`
+	Component string            `json:"component,omitempty"`
+}
+
+// castWriter writes the events of the terminal stream in the asciinema v2 format.
+// The header is written with the first size of the terminal, the default size is used,
+// if the stream starts before the size is known. The later sizes are written as the resize events.
+type castWriter struct {
+	mu            sync.Mutex
+	file          *os.File
+	start         time.Time
+	header        castHeader
+	headerWritten bool
+}
+
+func newCastWriter(path string, s *attachSession) (*castWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, err
+	}
+
+	return &castWriter{
+		file:  file,
+		start: s.start,
+		header: castHeader{
+			Version:   2,
+			Width:     castWidth,
+			Height:    castHeight,
+			Timestamp: s.start.Unix(),
+			Title:     fmt.Sprintf("%s/%s", s.namespace, s.name),
+			Env:       map[string]string{"TERM": "xterm-256color"},
+			User:      s.user,
+			Component: s.component,
+		},
+	}, nil
+}
+
+// WriteEvent writes the event of the code `i` for the input or `o` for the output.
+func (c *castWriter) WriteEvent(code string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeEventLocked(code, string(data))
+}
+
+// Resize writes the size of the terminal to the header, if the header is not written yet, or the resize event.
+func (c *castWriter) Resize(size remotecommand.TerminalSize) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.headerWritten {
+		c.header.Width, c.header.Height = int(size.Width), int(size.Height)
+		c.writeHeaderLocked()
+		return
+	}
+	c.writeEventLocked("r", fmt.Sprintf("%dx%d", size.Width, size.Height))
+}
+
+func (c *castWriter) writeHeaderLocked() {
+	c.headerWritten = true
+	header, err := json.Marshal(c.header)
+	if err != nil {
+		return
+	}
+	c.writeLocked(header)
+}
+
+func (c *castWriter) writeEventLocked(code, data string) {
+	if !c.headerWritten {
+		c.writeHeaderLocked()
+	}
+	event, err := json.Marshal([]any{time.Since(c.start).Seconds(), code, data})
+	if err != nil {
+		return
+	}
+	c.writeLocked(event)
+}
+
+func (c *castWriter) writeLocked(line []byte) {
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		slog.Error("Failed to write session recording", slog.String("recording", c.file.Name()), logging.SlogErr(err))
+	}
+}
+
+func (c *castWriter) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.headerWritten {
+		c.writeHeaderLocked()
+	}
+	return c.file.Close()
+}
+
+type castInputReader struct {
+	reader io.Reader
+	cast   *castWriter
+}
+
+func (r *castInputReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.cast.WriteEvent("i", p[:n])
+	}
+	return n, err
+}
+
+type castOutputWriter struct {
+	writer io.Writer
+	cast   *castWriter
+}
+
+func (w *castOutputWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if n > 0 {
+		w.cast.WriteEvent("o", p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	corelisters "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/listers/core/v1alpha1"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+)
+
+func NewSessionsREST(sandboxLister corelisters.SandboxLister, hub *SessionHub) *SessionsREST {
+	return &SessionsREST{
+		sandboxLister: sandboxLister,
+		hub:           hub,
+	}
+}
+
+// SessionsREST lists the live attach sessions of the sandbox.
+type SessionsREST struct {
+	sandboxLister corelisters.SandboxLister
+	hub           *SessionHub
+}
+
+var (
+	_ rest.Storage   = &SessionsREST{}
+	_ rest.Connecter = &SessionsREST{}
+)
+
+func (r SessionsREST) New() runtime.Object {
+	return &subv1alpha1.Sessions{}
+}
+
+func (r SessionsREST) Destroy() {}
+
+func (r SessionsREST) Connect(ctx context.Context, name string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.Sessions)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	namespace := genericreq.NamespaceValue(ctx)
+	if _, err := r.sandboxLister.Sandboxes(namespace).Get(name); err != nil {
+		return nil, err
+	}
+
+	list := &subv1alpha1.AttachSessionList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "AttachSessionList",
+			APIVersion: subv1alpha1.SchemeGroupVersion.String(),
+		},
+		Items: r.hub.list(namespace, name, options.Component),
+	}
+	return http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		responder.Object(http.StatusOK, list)
+	}), nil
+}
+
+func (r SessionsREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &subv1alpha1.Sessions{}, false, ""
+}
+
+func (r SessionsREST) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
@@ -0,0 +1,383 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+// SessionHub keeps the live attach sessions of the pod sandboxes.
+// The clients attached to the same sandbox component share the single stream to the container:
+// the output is sent to all of them, the input is accepted only from the driver.
+type SessionHub struct {
+	mu       sync.Mutex
+	sessions map[string]*sharedSession
+}
+
+func NewSessionHub() *SessionHub {
+	return &SessionHub{
+		sessions: make(map[string]*sharedSession),
+	}
+}
+
+func sessionKey(namespace, name, component string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, name, component)
+}
+
+func (h *SessionHub) exists(key string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.sessions[key]
+	return ok
+}
+
+// join adds the client to the live session or starts the new one, if there is no session and the client is not an observer.
+func (h *SessionHub) join(key string, c *sessionClient, newSession func() *sharedSession) (s *sharedSession, started bool, err error) {
+	h.mu.Lock()
+	s, ok := h.sessions[key]
+	if !ok {
+		if c.mode == subv1alpha1.AttachModeObserve {
+			h.mu.Unlock()
+			return nil, false, fmt.Errorf("no live session %q to observe", key)
+		}
+		s = newSession()
+		h.sessions[key] = s
+		started = true
+	}
+	notices := s.add(c)
+	h.mu.Unlock()
+
+	notices.send()
+	return s, started, nil
+}
+
+// serve passes the input of the client to the session until the client is disconnected.
+// The session is finished when the last client leaves.
+func (h *SessionHub) serve(s *sharedSession, c *sessionClient) {
+	defer h.leave(s, c)
+	c.conn.serve(func(p []byte) bool {
+		if !s.isDriver(c) {
+			return true
+		}
+		select {
+		case s.input <- p:
+			return true
+		case <-s.done:
+			return false
+		}
+	}, func(size remotecommand.TerminalSize) {
+		s.setSize(c, size)
+	})
+}
+
+func (h *SessionHub) leave(s *sharedSession, c *sessionClient) {
+	h.mu.Lock()
+	remaining, notices := s.remove(c)
+	if remaining == 0 {
+		h.deleteLocked(s)
+	}
+	h.mu.Unlock()
+
+	notices.send()
+	if remaining == 0 {
+		s.close(nil)
+	}
+}
+
+// finish stops the session and disconnects its clients with the result of the stream to the container.
+func (h *SessionHub) finish(s *sharedSession, err error) {
+	h.mu.Lock()
+	h.deleteLocked(s)
+	h.mu.Unlock()
+
+	s.close(err)
+}
+
+func (h *SessionHub) deleteLocked(s *sharedSession) {
+	if h.sessions[s.key] == s {
+		delete(h.sessions, s.key)
+	}
+}
+
+// list returns the live sessions of the sandbox, all components are listed if the component is empty.
+func (h *SessionHub) list(namespace, name, component string) []subv1alpha1.AttachSession {
+	prefix := sessionKey(namespace, name, "")
+	if component != "" {
+		prefix = sessionKey(namespace, name, component)
+	}
+
+	h.mu.Lock()
+	var sessions []*sharedSession
+	for key, s := range h.sessions {
+		if key == prefix || (component == "" && strings.HasPrefix(key, prefix)) {
+			sessions = append(sessions, s)
+		}
+	}
+	h.mu.Unlock()
+
+	items := make([]subv1alpha1.AttachSession, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, s.info())
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].StartTime.Before(&items[j].StartTime)
+	})
+	return items
+}
+
+// sharedSession is the stream to the container shared by the clients.
+// The identifier of the session is the identifier of the audit session, which started it, and of its recording.
+type sharedSession struct {
+	id        string
+	key       string
+	namespace string
+	name      string
+	component string
+	start     time.Time
+	cast      *castWriter
+
+	mu      sync.Mutex
+	clients []*sessionClient
+	driver  *sessionClient
+
+	input   chan []byte
+	pending []byte
+	sizes   chan remotecommand.TerminalSize
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newSharedSession(key string, s *attachSession, recordingDir string) *sharedSession {
+	shared := &sharedSession{
+		id:        s.id,
+		key:       key,
+		namespace: s.namespace,
+		name:      s.name,
+		component: s.component,
+		start:     time.Now(),
+		input:     make(chan []byte),
+		sizes:     make(chan remotecommand.TerminalSize, 1),
+		done:      make(chan struct{}),
+	}
+	if recordingDir != "" {
+		cast, err := newCastWriter(getRecordingPath(recordingDir, s.namespace, s.name, s.uid, s.id), s)
+		if err != nil {
+			slog.Error("Failed to start session recording", append(s.attrs(), logging.SlogErr(err))...)
+		}
+		shared.cast = cast
+	}
+	return shared
+}
+
+// add adds the client to the session, it returns the notices about the change of the driver to send after the locks are released.
+func (s *sharedSession) add(c *sessionClient) sessionNotices {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.clients = append(s.clients, c)
+	var notices sessionNotices
+	switch {
+	case c.mode == subv1alpha1.AttachModeObserve:
+		notices.add(c, "Joined the session %s read-only", s.id)
+	case c.mode == subv1alpha1.AttachModeControl && s.driver != nil:
+		notices.add(s.driver, "Control is taken by %s, the session is read-only", c.user)
+		s.driver = c
+		notices.add(c, "Took control of the session %s", s.id)
+	case s.driver == nil:
+		s.driver = c
+	default:
+		notices.add(c, "Joined the session %s read-only, it is driven by %s", s.id, s.driver.user)
+	}
+	return notices
+}
+
+// remove removes the client and passes control to the first client waiting for it,
+// it returns the number of the remaining clients and the notices to send after the locks are released.
+func (s *sharedSession) remove(c *sessionClient) (int, sessionNotices) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.clients {
+		if s.clients[i] == c {
+			s.clients = append(s.clients[:i], s.clients[i+1:]...)
+			break
+		}
+	}
+	if s.driver != c {
+		return len(s.clients), nil
+	}
+
+	var notices sessionNotices
+	s.driver = nil
+	for _, next := range s.clients {
+		if next.mode != subv1alpha1.AttachModeObserve {
+			s.driver = next
+			notices.add(next, "%s left, you have control of the session", c.user)
+			if next.size != nil {
+				s.resize(*next.size)
+			}
+			break
+		}
+	}
+	return len(s.clients), notices
+}
+
+// setSize keeps the size of the terminal of the client, the terminal is resized to the size of the driver.
+func (s *sharedSession) setSize(c *sessionClient, size remotecommand.TerminalSize) {
+	if size.Width == 0 || size.Height == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.size = &size
+	if s.driver == c {
+		s.resize(size)
+	}
+}
+
+// resize replaces the pending size of the terminal, only the latest size matters.
+func (s *sharedSession) resize(size remotecommand.TerminalSize) {
+	select {
+	case <-s.sizes:
+	default:
+	}
+	select {
+	case s.sizes <- size:
+	default:
+	}
+	if s.cast != nil {
+		s.cast.Resize(size)
+	}
+}
+
+// Next returns the size of the terminal of the driver, it implements remotecommand.TerminalSizeQueue.
+func (s *sharedSession) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-s.sizes:
+		return &size
+	case <-s.done:
+		return nil
+	}
+}
+
+func (s *sharedSession) isDriver(c *sessionClient) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.driver == c
+}
+
+func (s *sharedSession) info() subv1alpha1.AttachSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := subv1alpha1.AttachSession{
+		Session:   s.id,
+		Component: s.component,
+		StartTime: metav1.NewTime(s.start),
+	}
+	for _, c := range s.clients {
+		if c == s.driver {
+			info.Driver = c.user
+		} else {
+			info.Observers = append(info.Observers, c.user)
+		}
+	}
+	return info
+}
+
+// Read returns the input of the driver to the container.
+func (s *sharedSession) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		select {
+		case s.pending = <-s.input:
+		case <-s.done:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Write sends the output of the container to all clients, the client is dropped if it fails to receive it.
+func (s *sharedSession) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	clients := append([]*sessionClient(nil), s.clients...)
+	s.mu.Unlock()
+
+	for _, c := range clients {
+		if err := c.conn.write(p); err != nil {
+			c.conn.drop()
+		}
+	}
+	return len(p), nil
+}
+
+func (s *sharedSession) close(err error) {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		clients := append([]*sessionClient(nil), s.clients...)
+		s.mu.Unlock()
+
+		for _, c := range clients {
+			c.conn.close(err)
+		}
+	})
+}
+
+// clientConn is the connection of the client to the shared session.
+type clientConn interface {
+	// serve reads the client until it is disconnected, the input and the sizes of the terminal are passed to the callbacks.
+	// The input callback returns false, if the session is over.
+	serve(input func([]byte) bool, resize func(remotecommand.TerminalSize))
+	write(p []byte) error
+	// close disconnects the client with the result of the session.
+	close(err error)
+	// drop interrupts the reading of the client, so it leaves the session.
+	drop()
+}
+
+type sessionClient struct {
+	conn clientConn
+	user string
+	mode subv1alpha1.AttachMode
+	// size is the latest size of the terminal of the client, it is applied when the client takes control.
+	size *remotecommand.TerminalSize
+}
+
+// notice writes the message about the session to the terminal of the client, it is not recorded.
+// The write blocks on the slow client, so it is never called under the locks of the hub and the session.
+func (c *sessionClient) notice(message string) {
+	_ = c.conn.write([]byte("\r\n[sandbox] " + message + "\r\n"))
+}
+
+type sessionNotice struct {
+	client  *sessionClient
+	message string
+}
+
+// sessionNotices are collected under the locks and sent after they are released.
+type sessionNotices []sessionNotice
+
+func (n *sessionNotices) add(c *sessionClient, format string, args ...any) {
+	*n = append(*n, sessionNotice{client: c, message: fmt.Sprintf(format, args...)})
+}
+
+func (n sessionNotices) send() {
+	for _, notice := range n {
+		notice.client.notice(notice.message)
+	}
+}
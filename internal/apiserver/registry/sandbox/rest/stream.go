@@ -1,13 +1,19 @@
 package rest
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
 )
 
 type wsStreamReader struct {
@@ -34,6 +40,58 @@ func (w *wsStreamWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+const (
+	clientWriteTimeout = 10 * time.Second
+	clientCloseTimeout = 5 * time.Second
+)
+
+// wsClientConn is the client of the shared session connected with the plain or the resize protocol.
+// The input is sent as the binary messages, the control messages are sent as the text messages.
+type wsClientConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (c *wsClientConn) serve(input func([]byte) bool, resize func(remotecommand.TerminalSize)) {
+	for {
+		msgType, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType == websocket.TextMessage {
+			var control kubeclient.ControlMessage
+			if err = json.Unmarshal(msg, &control); err == nil && control.Type == kubeclient.ControlMessageResize {
+				resize(remotecommand.TerminalSize{Width: control.Width, Height: control.Height})
+			}
+			continue
+		}
+		if !input(msg) {
+			return
+		}
+	}
+}
+
+func (c *wsClientConn) write(p []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(clientWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.BinaryMessage, p)
+}
+
+// close sends the close message, the protocol has no exit status, so the error is not passed.
+func (c *wsClientConn) close(_ error) {
+	c.mu.Lock()
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "session finished"), time.Now().Add(clientWriteTimeout))
+	c.mu.Unlock()
+	_ = c.conn.SetReadDeadline(time.Now().Add(clientCloseTimeout))
+}
+
+func (c *wsClientConn) drop() {
+	_ = c.conn.SetReadDeadline(time.Now())
+}
+
 func isWebSocketRequest(req *http.Request) bool {
 	return strings.ToLower(req.Header.Get("Upgrade")) == "websocket" &&
 		strings.Contains(strings.ToLower(req.Header.Get("Connection")), "upgrade")
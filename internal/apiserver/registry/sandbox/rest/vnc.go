@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	configrest "k8s.io/client-go/rest"
+
+	corelisters "github.com/yaroslavborbat/sandbox-mommy/api/client/generated/listers/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/apiserver/registry/sandbox/client"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+)
+
+func NewVNCREST(sandboxLister corelisters.SandboxLister, client client.GenericClient, restConfig *configrest.Config) *VNCREST {
+	return &VNCREST{
+		sandboxLister: sandboxLister,
+		client:        client,
+		restConfig:    restConfig,
+	}
+}
+
+type VNCREST struct {
+	sandboxLister corelisters.SandboxLister
+	client        client.GenericClient
+	restConfig    *configrest.Config
+}
+
+var (
+	_ rest.Storage   = &VNCREST{}
+	_ rest.Connecter = &VNCREST{}
+)
+
+func (r VNCREST) New() runtime.Object {
+	return &subv1alpha1.VNC{}
+}
+
+func (r VNCREST) Destroy() {}
+
+func (r VNCREST) Connect(ctx context.Context, name string, opts runtime.Object, responder rest.Responder) (http.Handler, error) {
+	options, ok := opts.(*subv1alpha1.VNC)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	u, err := requestUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespace := genericreq.NamespaceValue(ctx)
+	// The console and the commands give the same access to the sandbox as the attachment.
+	if err = authorize(ctx, r.client, u, attachVerb, "attach", namespace, name); err != nil {
+		return nil, err
+	}
+	sandbox, err := r.sandboxLister.Sandboxes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sandbox, sandboxType, err := resolveTarget(sandbox, options.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = secrets.load(); err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+
+	nameDepsObj := common.GetFullName(sandbox)
+	switch sandboxType {
+	case v1alpha1.SandboxTypeKubevirtVMI:
+		kubevirtClient, err := r.client.Kubevirt()
+		if err != nil {
+			return nil, err
+		}
+		vmi, err := kubevirtClient.VirtualMachineInstance(common.GetChildNamespace(sandbox)).Get(ctx, nameDepsObj, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		remoteLocation, err := getKubevirtVMILocation(kubevirtClient, vmi, "vnc")
+		if err != nil {
+			return nil, err
+		}
+		return newProxyHandler(remoteLocation, u, responder)
+	case v1alpha1.SandboxTypeDVPVM:
+		dvpClient, err := r.client.DVP()
+		if err != nil {
+			return nil, err
+		}
+		vm, err := dvpClient.VirtualMachines(common.GetChildNamespace(sandbox)).Get(ctx, nameDepsObj, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		remoteLocation, err := getDVPVMLocation(r.restConfig, vm, "vnc")
+		if err != nil {
+			return nil, err
+		}
+		return newProxyHandler(remoteLocation, u, responder)
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("vnc is not supported for sandbox type %q", sandboxType))
+	}
+}
+
+func (r VNCREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return &subv1alpha1.VNC{}, false, ""
+}
+
+func (r VNCREST) ConnectMethods() []string {
+	return upgradeableMethods
+}
@@ -6,7 +6,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 	genericreq "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	configrest "k8s.io/client-go/rest"
@@ -21,6 +20,11 @@ type Storage struct {
 	sandboxLister corelisters.SandboxLister
 	groupResource schema.GroupResource
 	attach        *sandboxrest.AttachREST
+	exec          *sandboxrest.ExecREST
+	vnc           *sandboxrest.VNCREST
+	recordings    *sandboxrest.RecordingsREST
+	sessions      *sandboxrest.SessionsREST
+	consoleUI     *sandboxrest.ConsoleUIREST
 }
 
 var (
@@ -32,15 +36,22 @@ var (
 )
 
 func NewStorage(
-	serviceAccount types.NamespacedName,
 	sandboxLister corelisters.SandboxLister,
 	client client.GenericClient,
 	restConfig *configrest.Config,
+	recordingDir string,
+	consoleAssetsURL string,
 ) *Storage {
+	hub := sandboxrest.NewSessionHub()
 	return &Storage{
 		sandboxLister: sandboxLister,
 		groupResource: subv1alpha1.Resource("sandbox"),
-		attach:        sandboxrest.NewAttachREST(serviceAccount, sandboxLister, client, restConfig),
+		attach:        sandboxrest.NewAttachREST(sandboxLister, client, restConfig, hub, recordingDir),
+		exec:          sandboxrest.NewExecREST(sandboxLister, client, restConfig),
+		vnc:           sandboxrest.NewVNCREST(sandboxLister, client, restConfig),
+		recordings:    sandboxrest.NewRecordingsREST(sandboxLister, recordingDir),
+		sessions:      sandboxrest.NewSessionsREST(sandboxLister, hub),
+		consoleUI:     sandboxrest.NewConsoleUIREST(consoleAssetsURL),
 	}
 }
 
@@ -80,3 +91,23 @@ func (s Storage) GetSingularName() string {
 func (s Storage) AttachREST() *sandboxrest.AttachREST {
 	return s.attach
 }
+
+func (s Storage) ExecREST() *sandboxrest.ExecREST {
+	return s.exec
+}
+
+func (s Storage) VNCREST() *sandboxrest.VNCREST {
+	return s.vnc
+}
+
+func (s Storage) RecordingsREST() *sandboxrest.RecordingsREST {
+	return s.recordings
+}
+
+func (s Storage) SessionsREST() *sandboxrest.SessionsREST {
+	return s.sessions
+}
+
+func (s Storage) ConsoleUIREST() *sandboxrest.ConsoleUIREST {
+	return s.consoleUI
+}
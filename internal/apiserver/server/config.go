@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/types"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/rest"
 
@@ -15,9 +14,12 @@ import (
 var ErrConfigInvalid = errors.New("configuration is invalid")
 
 type Config struct {
-	Apiserver      *genericapiserver.Config
-	Rest           *rest.Config
-	ServiceAccount types.NamespacedName
+	Apiserver *genericapiserver.Config
+	Rest      *rest.Config
+	// RecordingDir is the directory of the recordings of the attach sessions, the recording is disabled if it is empty.
+	RecordingDir string
+	// ConsoleAssets is the base URL of the npm packages loaded by the web console, the vendored assets are served if it is empty.
+	ConsoleAssets string
 }
 
 func (c Config) Validate() error {
@@ -27,9 +29,6 @@ func (c Config) Validate() error {
 	if c.Rest == nil {
 		return fmt.Errorf("%w: %s", ErrConfigInvalid, "Rest is required")
 	}
-	if c.ServiceAccount.Name == "" || c.ServiceAccount.Namespace == "" {
-		return fmt.Errorf("%w: %s", ErrConfigInvalid, "ServiceAccount is required")
-	}
 	return nil
 }
 
@@ -54,8 +53,9 @@ func (c Config) Complete() (*Server, error) {
 		sandboxInformer.Lister(),
 		genericServer,
 		genericClient,
-		c.ServiceAccount,
 		c.Rest,
+		c.RecordingDir,
+		c.ConsoleAssets,
 	); err != nil {
 		return nil, err
 	}
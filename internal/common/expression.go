@@ -0,0 +1,79 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+)
+
+const ExpressionObjectsVariable = "objects"
+
+const (
+	// ExpressionCostLimit limits the runtime cost of the evaluation of the user-supplied expression.
+	ExpressionCostLimit = 1_000_000
+	// expressionInterruptCheckFrequency is the number of the comprehension iterations between the checks of the cancellation.
+	expressionInterruptCheckFrequency = 100
+	// expressionCacheSize limits the compiled programs kept in the cache, the cache is reset when it is full.
+	expressionCacheSize = 1024
+)
+
+var expressionCache = struct {
+	sync.Mutex
+	programs map[string]cel.Program
+}{programs: make(map[string]cel.Program)}
+
+// CompileExpression compiles the CEL expression evaluated against the list of sandbox objects.
+// The program stops with the error, when the cost of the evaluation exceeds ExpressionCostLimit.
+func CompileExpression(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable(ExpressionObjectsVariable, cel.ListType(cel.DynType)))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression %q: %w", expression, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression %q must return bool, got %s", expression, ast.OutputType())
+	}
+	return env.Program(ast,
+		cel.CostLimit(ExpressionCostLimit),
+		cel.InterruptCheckFrequency(expressionInterruptCheckFrequency),
+	)
+}
+
+// CachedExpression returns the compiled program of the expression, the expression is compiled once.
+// The program is cached by the expression, so the new generation of the template with the changed expression
+// is compiled again, and the inline templates of the sandboxes share the programs of the same expressions.
+func CachedExpression(expression string) (cel.Program, error) {
+	expressionCache.Lock()
+	defer expressionCache.Unlock()
+
+	if program, ok := expressionCache.programs[expression]; ok {
+		return program, nil
+	}
+	program, err := CompileExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(expressionCache.programs) >= expressionCacheSize {
+		clear(expressionCache.programs)
+	}
+	expressionCache.programs[expression] = program
+	return program, nil
+}
+
+func EvaluateExpression(program cel.Program, objects []interface{}) (bool, error) {
+	out, _, err := program.Eval(map[string]interface{}{
+		ExpressionObjectsVariable: objects,
+	})
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must return bool, got %T", out.Value())
+	}
+	return result, nil
+}
@@ -4,8 +4,43 @@ import "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
 
 const (
 	NamePrefix = "sandbox-"
+	// ComponentAnnotation is set on the in-memory copy of the sandbox, which is passed to the sandboxer of a component.
+	ComponentAnnotation = "sandbox.io/component"
 )
 
+// GetID returns the identifier of the sandbox or its component, which is a part of the names of the child resources.
+func GetID(sandbox *v1alpha1.Sandbox) string {
+	id := string(sandbox.GetUID())
+	if component := GetComponent(sandbox); component != "" {
+		id += "-" + component
+	}
+	return id
+}
+
 func GetFullName(sandbox *v1alpha1.Sandbox) string {
-	return NamePrefix + string(sandbox.GetUID())
+	return NamePrefix + GetID(sandbox)
+}
+
+// GetChildNamespace returns the namespace of the child resources, it is the dedicated namespace if it is created.
+func GetChildNamespace(sandbox *v1alpha1.Sandbox) string {
+	if sandbox.Status.Namespace != "" {
+		return sandbox.Status.Namespace
+	}
+	return sandbox.GetNamespace()
+}
+
+func GetComponent(sandbox *v1alpha1.Sandbox) string {
+	return sandbox.GetAnnotations()[ComponentAnnotation]
+}
+
+// WithComponent returns the copy of the sandbox, the child resources of which belong to the component.
+func WithComponent(sandbox *v1alpha1.Sandbox, component string) *v1alpha1.Sandbox {
+	sandbox = sandbox.DeepCopy()
+	annotations := sandbox.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ComponentAnnotation] = component
+	sandbox.SetAnnotations(annotations)
+	return sandbox
 }
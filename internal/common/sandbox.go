@@ -1,6 +1,10 @@
 package common
 
-import "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+import (
+	"fmt"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
 
 func DetectSandboxType(spec *v1alpha1.SandboxTemplateSpec) v1alpha1.SandboxType {
 	if spec == nil {
@@ -13,7 +17,68 @@ func DetectSandboxType(spec *v1alpha1.SandboxTemplateSpec) v1alpha1.SandboxType
 		return v1alpha1.SandboxTypeKubevirtVMI
 	case spec.DVPVMSpec != nil:
 		return v1alpha1.SandboxTypeDVPVM
+	case spec.UnstructuredSpec != nil:
+		return v1alpha1.SandboxTypeUnstructured
+	case len(spec.Components) > 0:
+		return v1alpha1.SandboxTypeComposite
+	case spec.PluginSpec != nil:
+		return v1alpha1.NewPluginSandboxType(spec.PluginSpec.Backend)
 	default:
 		return ""
 	}
 }
+
+// GetComponentTemplateSpec returns the template spec of the single component sandbox.
+func GetComponentTemplateSpec(component v1alpha1.SandboxComponent) *v1alpha1.SandboxTemplateSpec {
+	return &v1alpha1.SandboxTemplateSpec{
+		PodSpec:         component.PodSpec,
+		KubevirtVMISpec: component.KubevirtVMISpec,
+		DVPVMSpec:       component.DVPVMSpec,
+		Volumes:         component.Volumes,
+	}
+}
+
+// SortComponents returns the components in the dependency order, the order of the independent components is kept.
+func SortComponents(components []v1alpha1.SandboxComponent) ([]v1alpha1.SandboxComponent, error) {
+	indexes := make(map[string]int, len(components))
+	for i, component := range components {
+		if _, exist := indexes[component.Name]; exist {
+			return nil, fmt.Errorf("component %q already exists", component.Name)
+		}
+		indexes[component.Name] = i
+	}
+	for _, component := range components {
+		for _, dep := range component.DependsOn {
+			if _, exist := indexes[dep]; !exist {
+				return nil, fmt.Errorf("component %q depends on unknown component %q", component.Name, dep)
+			}
+		}
+	}
+
+	sorted := make([]v1alpha1.SandboxComponent, 0, len(components))
+	added := make(map[string]struct{}, len(components))
+	for len(sorted) < len(components) {
+		progress := false
+		for _, component := range components {
+			if _, ok := added[component.Name]; ok {
+				continue
+			}
+			resolved := true
+			for _, dep := range component.DependsOn {
+				if _, ok := added[dep]; !ok {
+					resolved = false
+					break
+				}
+			}
+			if resolved {
+				sorted = append(sorted, component)
+				added[component.Name] = struct{}{}
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, fmt.Errorf("components have a dependency cycle")
+		}
+	}
+	return sorted, nil
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+func TestSortComponents(t *testing.T) {
+	component := func(name string, dependsOn ...string) v1alpha1.SandboxComponent {
+		return v1alpha1.SandboxComponent{Name: name, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name       string
+		components []v1alpha1.SandboxComponent
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "independent components keep the order",
+			components: []v1alpha1.SandboxComponent{component("b"), component("a"), component("c")},
+			want:       []string{"b", "a", "c"},
+		},
+		{
+			name:       "dependencies go first",
+			components: []v1alpha1.SandboxComponent{component("app", "db", "cache"), component("cache", "db"), component("db")},
+			want:       []string{"db", "cache", "app"},
+		},
+		{
+			name:       "diamond",
+			components: []v1alpha1.SandboxComponent{component("d", "b", "c"), component("b", "a"), component("c", "a"), component("a")},
+			want:       []string{"a", "b", "c", "d"},
+		},
+		{
+			name:       "duplicate name",
+			components: []v1alpha1.SandboxComponent{component("a"), component("a")},
+			wantErr:    `component "a" already exists`,
+		},
+		{
+			name:       "unknown dependency",
+			components: []v1alpha1.SandboxComponent{component("app", "db")},
+			wantErr:    `component "app" depends on unknown component "db"`,
+		},
+		{
+			name:       "self dependency",
+			components: []v1alpha1.SandboxComponent{component("a", "a")},
+			wantErr:    "components have a dependency cycle",
+		},
+		{
+			name:       "cycle",
+			components: []v1alpha1.SandboxComponent{component("a", "c"), component("b", "a"), component("c", "b"), component("d")},
+			wantErr:    "components have a dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := SortComponents(tt.components)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("SortComponents() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SortComponents() unexpected error: %v", err)
+			}
+			var names []string
+			for _, c := range sorted {
+				names = append(names, c.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("SortComponents() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package sandbox
+
+import (
+	"context"
+
+	dvpcorev1alpha2 "github.com/deckhouse/virtualization/api/core/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
+)
+
+// indexSandboxID is the field index of the child resources by the identifier of the sandbox or its component.
+const indexSandboxID = "sandbox.io/id"
+
+func setupIndexers(ctx context.Context, mgr ctrl.Manager) error {
+	objs := []client.Object{&corev1.PersistentVolumeClaim{}}
+	if featuregate.Enabled(featuregate.Kubevirt) {
+		objs = append(objs, &cdiv1beta1.DataVolume{})
+	}
+	if featuregate.Enabled(featuregate.DVP) {
+		objs = append(objs, &dvpcorev1alpha2.VirtualDisk{})
+	}
+
+	for _, obj := range objs {
+		if err := mgr.GetFieldIndexer().IndexField(ctx, obj, indexSandboxID, indexBySandboxID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexBySandboxID returns the same identifier as common.GetID for the child created by newChildLabels.
+func indexBySandboxID(obj client.Object) []string {
+	labels := obj.GetLabels()
+	id := labels[v1alpha1.LabelSandboxUID]
+	if id == "" {
+		return nil
+	}
+	if component := labels[labelSandboxComponent]; component != "" {
+		id += "-" + component
+	}
+	return []string{id}
+}
+
+// listChildren lists the child resources of the sandbox or its component using the field index,
+// the type of the list must be registered in setupIndexers.
+func listChildren(ctx context.Context, c client.Reader, sandbox *v1alpha1.Sandbox, list client.ObjectList) error {
+	return c.List(ctx, list,
+		client.InNamespace(common.GetChildNamespace(sandbox)),
+		client.MatchingFields{indexSandboxID: common.GetID(sandbox)},
+	)
+}
@@ -0,0 +1,146 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+const (
+	benchmarkNamespace = "default"
+	benchmarkUnrelated = 10000
+	benchmarkChildren  = 3
+)
+
+// indexerReader reads the claims from the store of the informer like the cached client of the manager:
+// the exact field selector is served by the field index scoped by the namespace, otherwise all objects
+// of the namespace are read, and every returned object is copied.
+type indexerReader struct {
+	indexer cache.Indexer
+}
+
+func newIndexerReader() *indexerReader {
+	return &indexerReader{indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		"field:" + indexSandboxID: func(obj interface{}) ([]string, error) {
+			o := obj.(client.Object)
+			var keys []string
+			for _, value := range indexBySandboxID(o) {
+				keys = append(keys, o.GetNamespace()+"/"+value)
+			}
+			return keys, nil
+		},
+	})}
+}
+
+func (r *indexerReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (r *indexerReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	pvcs, ok := list.(*corev1.PersistentVolumeClaimList)
+	if !ok {
+		return fmt.Errorf("unsupported list %T", list)
+	}
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	index, value := cache.NamespaceIndex, listOpts.Namespace
+	if listOpts.FieldSelector != nil {
+		requirements := listOpts.FieldSelector.Requirements()
+		if len(requirements) != 1 {
+			return fmt.Errorf("unsupported field selector %s", listOpts.FieldSelector)
+		}
+		index, value = "field:"+requirements[0].Field, listOpts.Namespace+"/"+requirements[0].Value
+	}
+
+	items, err := r.indexer.ByIndex(index, value)
+	if err != nil {
+		return err
+	}
+	pvcs.Items = make([]corev1.PersistentVolumeClaim, 0, len(items))
+	for _, item := range items {
+		pvcs.Items = append(pvcs.Items, *item.(*corev1.PersistentVolumeClaim).DeepCopy())
+	}
+	return nil
+}
+
+func newBenchmarkSandbox(name string) *v1alpha1.Sandbox {
+	return &v1alpha1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: benchmarkNamespace,
+			UID:       types.UID("uid-" + name),
+		},
+	}
+}
+
+func newBenchmarkPVC(name string, owner *v1alpha1.Sandbox) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       benchmarkNamespace,
+			Labels:          map[string]string{v1alpha1.LabelSandboxUID: string(owner.UID)},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind))},
+		},
+	}
+}
+
+// BenchmarkListChildren compares the lookup of the child claims through the field index
+// with the former listing of the whole namespace filtered by the controller reference,
+// the namespace has the claims of the sandbox and 10k unrelated claims of the other sandboxes.
+func BenchmarkListChildren(b *testing.B) {
+	ctx := context.Background()
+	sandbox := newBenchmarkSandbox("sandbox")
+
+	r := newIndexerReader()
+	for i := range benchmarkChildren {
+		if err := r.indexer.Add(newBenchmarkPVC(fmt.Sprintf("child-%d", i), sandbox)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := range benchmarkUnrelated {
+		other := newBenchmarkSandbox(fmt.Sprintf("other-%d", i))
+		if err := r.indexer.Add(newBenchmarkPVC(fmt.Sprintf("unrelated-%d", i), other)); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("Index", func(b *testing.B) {
+		for range b.N {
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			if err := listChildren(ctx, r, sandbox, pvcs); err != nil {
+				b.Fatal(err)
+			}
+			if len(pvcs.Items) != benchmarkChildren {
+				b.Fatalf("expected %d children, got %d", benchmarkChildren, len(pvcs.Items))
+			}
+		}
+	})
+
+	b.Run("FullList", func(b *testing.B) {
+		for range b.N {
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			if err := r.List(ctx, pvcs, client.InNamespace(benchmarkNamespace)); err != nil {
+				b.Fatal(err)
+			}
+			var children []*corev1.PersistentVolumeClaim
+			for i := range pvcs.Items {
+				if metav1.IsControlledBy(&pvcs.Items[i], sandbox) {
+					children = append(children, &pvcs.Items[i])
+				}
+			}
+			if len(children) != benchmarkChildren {
+				b.Fatalf("expected %d children, got %d", benchmarkChildren, len(children))
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+)
+
+const (
+	cloudInitSecretNamePrefix = "sandbox-cloudinit-"
+	cloudInitUserDataKey      = "userData"
+	cloudConfigHeader         = "#cloud-config"
+	cloudConfigSSHKeysField   = "ssh_authorized_keys"
+)
+
+func getFullCloudInitSecretName(sandbox *v1alpha1.Sandbox) string {
+	return cloudInitSecretNamePrefix + common.GetID(sandbox)
+}
+
+func getSSHKeys(sandbox *v1alpha1.Sandbox) []string {
+	if sandbox.Spec.Access == nil {
+		return nil
+	}
+	return sandbox.Spec.Access.SSHKeys
+}
+
+// mergeSSHKeys adds the keys to the ssh_authorized_keys list of the cloud-config user data.
+func mergeSSHKeys(userData string, keys []string) (string, error) {
+	cloudConfig := make(map[string]interface{})
+	if strings.TrimSpace(userData) != "" {
+		if !strings.HasPrefix(userData, cloudConfigHeader) {
+			return "", fmt.Errorf("ssh keys can be merged only into the %q user data", cloudConfigHeader)
+		}
+		if err := yaml.Unmarshal([]byte(userData), &cloudConfig); err != nil {
+			return "", fmt.Errorf("failed to parse cloud-config user data: %w", err)
+		}
+	}
+
+	var authorizedKeys []string
+	if existing, ok := cloudConfig[cloudConfigSSHKeysField].([]interface{}); ok {
+		for _, key := range existing {
+			if s, ok := key.(string); ok {
+				authorizedKeys = append(authorizedKeys, s)
+			}
+		}
+	}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key != "" && !slices.Contains(authorizedKeys, key) {
+			authorizedKeys = append(authorizedKeys, key)
+		}
+	}
+	cloudConfig[cloudConfigSSHKeysField] = authorizedKeys
+
+	bytes, err := yaml.Marshal(cloudConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cloud-config user data: %w", err)
+	}
+
+	return cloudConfigHeader + "\n" + string(bytes), nil
+}
+
+func newCloudInitSecret(sandbox *v1alpha1.Sandbox, secretType corev1.SecretType, userData string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            getFullCloudInitSecretName(sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
+		},
+		Type: secretType,
+		StringData: map[string]string{
+			cloudInitUserDataKey: userData,
+		},
+	}
+}
+
+type cloudInitManager struct {
+	client client.Client
+}
+
+func (m cloudInitManager) createSecret(ctx context.Context, sandbox *v1alpha1.Sandbox, secretType corev1.SecretType, userData string) error {
+	secret := newCloudInitSecret(sandbox, secretType, userData)
+	err := m.client.Create(ctx, secret)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create secret %q: %w", client.ObjectKeyFromObject(secret).String(), err)
+	}
+	return nil
+}
+
+func (m cloudInitManager) deleteSecret(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	secret := &corev1.Secret{}
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: common.GetChildNamespace(sandbox), Name: getFullCloudInitSecretName(sandbox)}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get secret %w", err)
+	}
+	if err = m.client.Delete(ctx, secret); err != nil {
+		return fmt.Errorf("failed to delete secret %q", client.ObjectKeyFromObject(secret).String())
+	}
+	return nil
+}
+
+func (m cloudInitManager) getSecretUserData(ctx context.Context, namespace, name string, keys ...string) (string, error) {
+	secret := &corev1.Secret{}
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cloud-init secret %q: %w", name, err)
+	}
+	for _, key := range keys {
+		if data, ok := secret.Data[key]; ok {
+			return string(data), nil
+		}
+	}
+	return "", fmt.Errorf("cloud-init secret %q has no user data", name)
+}
@@ -0,0 +1,94 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+const (
+	artifactsPVCNamePrefix = "sandbox-artifacts-"
+	artifactsVolumeName    = "sandbox-artifacts"
+	// labelArtifactsOf is set instead of v1alpha1.LabelSandboxUID, the artifacts claim outlives the sandbox.
+	labelArtifactsOf = "sandbox.io/artifacts-of"
+)
+
+// Completer is implemented by the sandboxers, which support the run-to-completion mode.
+type Completer interface {
+	// Result returns the result of the finished workload, nil if the workload is not finished.
+	Result(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) (*v1alpha1.SandboxResult, error)
+}
+
+func isCompletion(templateSpec *v1alpha1.SandboxTemplateSpec) bool {
+	return templateSpec != nil && templateSpec.Completion != nil
+}
+
+func getArtifacts(templateSpec *v1alpha1.SandboxTemplateSpec) *v1alpha1.ArtifactsSpec {
+	if !isCompletion(templateSpec) {
+		return nil
+	}
+	return templateSpec.Completion.Artifacts
+}
+
+func getFullArtifactsPVCName(sandbox *v1alpha1.Sandbox) string {
+	return artifactsPVCNamePrefix + string(sandbox.GetUID())
+}
+
+// newArtifactsPVC returns the claim without the owner reference, so it is retained after the sandbox deletion.
+func newArtifactsPVC(sandbox *v1alpha1.Sandbox, artifacts *v1alpha1.ArtifactsSpec) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getFullArtifactsPVCName(sandbox),
+			Namespace: sandbox.GetNamespace(),
+			Labels: map[string]string{
+				labelArtifactsOf: sandbox.GetName(),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: artifacts.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: artifacts.Size,
+				},
+			},
+		},
+	}
+}
+
+func (m pvcManager) createArtifactsPVC(ctx context.Context, sandbox *v1alpha1.Sandbox, artifacts *v1alpha1.ArtifactsSpec) error {
+	pvc := newArtifactsPVC(sandbox, artifacts)
+	err := m.client.Create(ctx, pvc)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create artifacts pvc %q: %w", client.ObjectKeyFromObject(pvc).String(), err)
+	}
+	return nil
+}
+
+// mutatePodArtifacts mounts the artifacts claim at the declared path into all containers of the pod.
+func mutatePodArtifacts(sandbox *v1alpha1.Sandbox, pod *corev1.Pod, artifacts *v1alpha1.ArtifactsSpec) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: artifactsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: getFullArtifactsPVCName(sandbox),
+			},
+		},
+	})
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      artifactsVolumeName,
+			MountPath: artifacts.Path,
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+)
+
+func NewCompositeSandboxer(client client.Client, log *slog.Logger) *CompositeSandboxer {
+	return &CompositeSandboxer{
+		client: client,
+		log:    log,
+	}
+}
+
+// CompositeSandboxer runs every component by the sandboxer of its type.
+// The component resources are named by the copy of the sandbox with the component annotation.
+type CompositeSandboxer struct {
+	client client.Client
+	log    *slog.Logger
+}
+
+// Create creates the components in the dependency order,
+// a component is created only when all its dependencies are ready.
+func (p CompositeSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) error {
+	components, err := common.SortComponents(templateSpec.Components)
+	if err != nil {
+		return err
+	}
+
+	ready := make(map[string]struct{})
+	for _, component := range components {
+		if !dependenciesReady(component, ready) {
+			continue
+		}
+
+		componentSandbox, componentSpec, sandboxer := p.componentSandboxer(sandbox, component)
+		if sandboxer == nil {
+			return fmt.Errorf("component %q has unknown type", component.Name)
+		}
+		if err = sandboxer.Create(ctx, componentSandbox, componentSpec); err != nil {
+			return fmt.Errorf("failed to create component %q: %w", component.Name, err)
+		}
+		status, _, _, err := sandboxer.Status(ctx, componentSandbox)
+		if err != nil {
+			return fmt.Errorf("failed to get status of component %q: %w", component.Name, err)
+		}
+		if status == metav1.ConditionTrue {
+			ready[component.Name] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the components in the reverse dependency order.
+func (p CompositeSandboxer) Delete(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	components, err := p.getComponents(ctx, sandbox)
+	if err != nil {
+		return err
+	}
+	if components == nil {
+		p.log.Warn("Cannot get components, that's why child resources can be deleted in background")
+		return nil
+	}
+
+	for _, component := range slices.Backward(components) {
+		componentSandbox, _, sandboxer := p.componentSandboxer(sandbox, component)
+		if sandboxer == nil {
+			continue
+		}
+		if err = sandboxer.Delete(ctx, componentSandbox); err != nil {
+			return fmt.Errorf("failed to delete component %q: %w", component.Name, err)
+		}
+	}
+	return nil
+}
+
+// Status aggregates the statuses of the components, the status of every component is stored in the sandbox status.
+func (p CompositeSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (metav1.ConditionStatus, sandboxcondition.Reason, string, error) {
+	components, err := p.getComponents(ctx, sandbox)
+	if err != nil || components == nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+
+	var (
+		statuses = make([]v1alpha1.SandboxComponentStatus, 0, len(components))
+		ready    = make(map[string]struct{})
+		failed   []string
+		pending  []string
+	)
+	for _, component := range components {
+		componentSandbox, componentSpec, sandboxer := p.componentSandboxer(sandbox, component)
+		componentStatus := v1alpha1.SandboxComponentStatus{
+			Name:   component.Name,
+			Type:   common.DetectSandboxType(componentSpec),
+			Status: metav1.ConditionFalse,
+			Reason: sandboxcondition.ReasonPending.String(),
+		}
+
+		switch {
+		case sandboxer == nil:
+			componentStatus.Reason = sandboxcondition.ReasonFailed.String()
+			componentStatus.Message = "Unknown component type."
+		case !dependenciesReady(component, ready):
+			componentStatus.Message = fmt.Sprintf("Waiting for dependencies: %s.", strings.Join(component.DependsOn, ", "))
+		default:
+			status, reason, message, err := sandboxer.Status(ctx, componentSandbox)
+			if err != nil {
+				return metav1.ConditionUnknown, "", "", fmt.Errorf("failed to get status of component %q: %w", component.Name, err)
+			}
+			componentStatus.Status = status
+			componentStatus.Reason = reason.String()
+			componentStatus.Message = message
+		}
+
+		switch {
+		case componentStatus.Status == metav1.ConditionTrue:
+			ready[component.Name] = struct{}{}
+		case componentStatus.Reason == sandboxcondition.ReasonFailed.String():
+			failed = append(failed, component.Name)
+		default:
+			pending = append(pending, component.Name)
+		}
+		statuses = append(statuses, componentStatus)
+	}
+	sandbox.Status.Components = statuses
+
+	switch {
+	case len(failed) > 0:
+		return metav1.ConditionFalse, sandboxcondition.ReasonFailed, fmt.Sprintf("Components are failed: %s.", strings.Join(failed, ", ")), nil
+	case len(pending) > 0:
+		return metav1.ConditionFalse, sandboxcondition.ReasonPending, fmt.Sprintf("Components are not ready: %s.", strings.Join(pending, ", ")), nil
+	default:
+		return metav1.ConditionTrue, sandboxcondition.ReasonReady, "", nil
+	}
+}
+
+func (p CompositeSandboxer) componentSandboxer(sandbox *v1alpha1.Sandbox, component v1alpha1.SandboxComponent) (*v1alpha1.Sandbox, *v1alpha1.SandboxTemplateSpec, Sandboxer) {
+	componentSpec := common.GetComponentTemplateSpec(component)
+	sandboxer := NewSandboxer(common.DetectSandboxType(componentSpec), p.client, p.log.With(slog.String("component", component.Name)))
+	return common.WithComponent(sandbox, component.Name), componentSpec, sandboxer
+}
+
+// getComponents returns the sorted components of the sandbox template, nil if the template is not found.
+func (p CompositeSandboxer) getComponents(ctx context.Context, sandbox *v1alpha1.Sandbox) ([]v1alpha1.SandboxComponent, error) {
+	templateSpec, err := getTemplateSpec(ctx, p.client, sandbox)
+	if err != nil || templateSpec == nil {
+		return nil, err
+	}
+	return common.SortComponents(templateSpec.Components)
+}
+
+func dependenciesReady(component v1alpha1.SandboxComponent, ready map[string]struct{}) bool {
+	for _, dep := range component.DependsOn {
+		if _, ok := ready[dep]; !ok {
+			return false
+		}
+	}
+	return true
+}
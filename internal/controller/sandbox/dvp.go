@@ -20,13 +20,17 @@ import (
 func NewDVPSandboxer(client client.Client, log *slog.Logger) *DVPSandboxer {
 	return &DVPSandboxer{
 		client: client,
-		log:    log,
+		cloudInitManager: cloudInitManager{
+			client: client,
+		},
+		log: log,
 	}
 }
 
 type DVPSandboxer struct {
-	client client.Client
-	log    *slog.Logger
+	client           client.Client
+	cloudInitManager cloudInitManager
+	log              *slog.Logger
 }
 
 func (p DVPSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) error {
@@ -62,11 +66,20 @@ func (p DVPSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, tem
 			return nil
 		}
 
-		return p.client.Delete(ctx, vm)
+		if err = p.client.Delete(ctx, vm); err != nil {
+			return fmt.Errorf("failed to restart virtual machine %q", client.ObjectKeyFromObject(vm).String())
+		}
+		restarts.WithLabelValues(string(v1alpha1.SandboxTypeDVPVM)).Inc()
+		return nil
 	}
 	if templateSpec.DVPVMSpec != nil {
 		vm = newDVPVM(sandbox, *templateSpec.DVPVMSpec)
 		mutateDVPVMVolumes(sandbox, vm, vdsForCreate)
+		if keys := getSSHKeys(sandbox); len(keys) > 0 {
+			if err = p.injectSSHKeys(ctx, sandbox, vm, keys); err != nil {
+				return err
+			}
+		}
 
 		if err = p.client.Create(ctx, vm); err != nil {
 			return fmt.Errorf("failed to create virtual machine %q", client.ObjectKeyFromObject(vm).String())
@@ -99,7 +112,7 @@ func (p DVPSandboxer) Delete(ctx context.Context, sandbox *v1alpha1.Sandbox) err
 		}
 	}
 
-	return nil
+	return p.cloudInitManager.deleteSecret(ctx, sandbox)
 }
 
 func (p DVPSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (metav1.ConditionStatus, sandboxcondition.Reason, string, error) {
@@ -137,9 +150,50 @@ func (p DVPSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (me
 
 }
 
+// injectSSHKeys merges the keys into the provisioning user data of the vm.
+// The merged user data is stored in the per-sandbox secret, the vm refers to it.
+func (p DVPSandboxer) injectSSHKeys(ctx context.Context, sandbox *v1alpha1.Sandbox, vm *dvpcorev1alpha2.VirtualMachine, keys []string) error {
+	var userData string
+	if provisioning := vm.Spec.Provisioning; provisioning != nil {
+		switch provisioning.Type {
+		case dvpcorev1alpha2.ProvisioningTypeUserData:
+			userData = provisioning.UserData
+		case dvpcorev1alpha2.ProvisioningTypeUserDataRef:
+			if provisioning.UserDataRef == nil {
+				return fmt.Errorf("provisioning userDataRef is not specified")
+			}
+			data, err := p.cloudInitManager.getSecretUserData(ctx, sandbox.GetNamespace(), provisioning.UserDataRef.Name, cloudInitUserDataKey)
+			if err != nil {
+				return err
+			}
+			userData = data
+		default:
+			return fmt.Errorf("ssh keys injection is not supported for provisioning type %q", provisioning.Type)
+		}
+	}
+
+	userData, err := mergeSSHKeys(userData, keys)
+	if err != nil {
+		return err
+	}
+	if err = p.cloudInitManager.createSecret(ctx, sandbox, dvpcorev1alpha2.SecretTypeCloudInit, userData); err != nil {
+		return err
+	}
+
+	vm.Spec.Provisioning = &dvpcorev1alpha2.Provisioning{
+		Type: dvpcorev1alpha2.ProvisioningTypeUserDataRef,
+		UserDataRef: &dvpcorev1alpha2.UserDataRef{
+			Kind: dvpcorev1alpha2.UserDataRefKindSecret,
+			Name: getFullCloudInitSecretName(sandbox),
+		},
+	}
+
+	return nil
+}
+
 func (p DVPSandboxer) getVM(ctx context.Context, sandbox *v1alpha1.Sandbox) (*dvpcorev1alpha2.VirtualMachine, error) {
 	vm := &dvpcorev1alpha2.VirtualMachine{}
-	err := p.client.Get(ctx, client.ObjectKey{Namespace: sandbox.GetNamespace(), Name: common.GetFullName(sandbox)}, vm)
+	err := p.client.Get(ctx, client.ObjectKey{Namespace: common.GetChildNamespace(sandbox), Name: common.GetFullName(sandbox)}, vm)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -151,16 +205,13 @@ func (p DVPSandboxer) getVM(ctx context.Context, sandbox *v1alpha1.Sandbox) (*dv
 
 func (p DVPSandboxer) getVDs(ctx context.Context, sandbox *v1alpha1.Sandbox) ([]*dvpcorev1alpha2.VirtualDisk, error) {
 	vds := &dvpcorev1alpha2.VirtualDiskList{}
-	err := p.client.List(ctx, vds, client.InNamespace(sandbox.GetNamespace()))
-	if err != nil {
+	if err := listChildren(ctx, p.client, sandbox, vds); err != nil {
 		return nil, fmt.Errorf("failed to list VirtualDisks %w", err)
 	}
 
-	var result []*dvpcorev1alpha2.VirtualDisk
-	for _, pvc := range vds.Items {
-		if metav1.IsControlledBy(&pvc, sandbox) {
-			result = append(result, &pvc)
-		}
+	result := make([]*dvpcorev1alpha2.VirtualDisk, 0, len(vds.Items))
+	for i := range vds.Items {
+		result = append(result, &vds.Items[i])
 	}
 
 	return result, nil
@@ -183,14 +234,10 @@ func newDVPVM(sandbox *v1alpha1.Sandbox, spec dvpcorev1alpha2.VirtualMachineSpec
 			APIVersion: dvpcorev1alpha2.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.GetFullName(sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            common.GetFullName(sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -219,14 +266,10 @@ func newVirtualDisk(sandbox *v1alpha1.Sandbox, name string, spec dvpcorev1alpha2
 			APIVersion: dvpcorev1alpha2.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getFullVDName(name, sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            getFullVDName(name, sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -235,7 +278,7 @@ func newVirtualDisk(sandbox *v1alpha1.Sandbox, name string, spec dvpcorev1alpha2
 const vdNamePrefix = "sandbox-vd-"
 
 func getFullVDName(name string, sandbox *v1alpha1.Sandbox) string {
-	return fmt.Sprintf("%s%s-%s", vdNamePrefix, sandbox.GetUID(), name)
+	return fmt.Sprintf("%s%s-%s", vdNamePrefix, common.GetID(sandbox), name)
 }
 
 func getReasonFromDVPVMPhase(phase dvpcorev1alpha2.MachinePhase) sandboxcondition.Reason {
@@ -2,6 +2,7 @@ package sandbox
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -25,14 +26,18 @@ func NewKubevirtSandboxer(client client.Client, log *slog.Logger) *KubevirtSandb
 		pvcManager: pvcManager{
 			client: client,
 		},
+		cloudInitManager: cloudInitManager{
+			client: client,
+		},
 		log: log,
 	}
 }
 
 type KubevirtSandboxer struct {
-	client     client.Client
-	pvcManager pvcManager
-	log        *slog.Logger
+	client           client.Client
+	pvcManager       pvcManager
+	cloudInitManager cloudInitManager
+	log              *slog.Logger
 }
 
 func (p KubevirtSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) error {
@@ -69,15 +74,24 @@ func (p KubevirtSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox
 	}
 	if vmi != nil {
 		reason := getReasonFromKubevirtVMIPhase(vmi.Status.Phase)
-		if reason != sandboxcondition.ReasonFailed {
+		if reason != sandboxcondition.ReasonFailed || isCompletion(templateSpec) {
 			return nil
 		}
 
-		return p.client.Delete(ctx, vmi)
+		if err = p.client.Delete(ctx, vmi); err != nil {
+			return fmt.Errorf("failed to restart virtual machine instance %q", client.ObjectKeyFromObject(vmi).String())
+		}
+		restarts.WithLabelValues(string(v1alpha1.SandboxTypeKubevirtVMI)).Inc()
+		return nil
 	}
 	if templateSpec.KubevirtVMISpec != nil {
 		vmi = newKubevirtVMI(sandbox, *templateSpec.KubevirtVMISpec)
 		mutateKubevirtVMIVolumes(sandbox, vmi, dvsForCreate, pvcsForCreate)
+		if keys := getSSHKeys(sandbox); len(keys) > 0 {
+			if err = p.injectSSHKeys(ctx, sandbox, vmi, keys); err != nil {
+				return err
+			}
+		}
 		if err = p.client.Create(ctx, vmi); err != nil {
 			return fmt.Errorf("failed to create virtual machine instance %q", client.ObjectKeyFromObject(vmi).String())
 		}
@@ -108,6 +122,9 @@ func (p KubevirtSandboxer) Delete(ctx context.Context, sandbox *v1alpha1.Sandbox
 			return fmt.Errorf("failed to delete data volume %q", client.ObjectKeyFromObject(vd).String())
 		}
 	}
+	if err = p.cloudInitManager.deleteSecret(ctx, sandbox); err != nil {
+		return err
+	}
 
 	return p.pvcManager.deletePVCs(ctx, sandbox)
 }
@@ -147,9 +164,112 @@ func (p KubevirtSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox
 
 }
 
+func (p KubevirtSandboxer) Result(ctx context.Context, sandbox *v1alpha1.Sandbox, _ *v1alpha1.SandboxTemplateSpec) (*v1alpha1.SandboxResult, error) {
+	if !featuregate.Enabled(featuregate.Kubevirt) {
+		return nil, fmt.Errorf("featuregate %s is not enabled", featuregate.Kubevirt)
+	}
+
+	vmi, err := p.getVMI(ctx, sandbox)
+	if err != nil || vmi == nil {
+		return nil, err
+	}
+
+	result := &v1alpha1.SandboxResult{
+		FinishedAt: metav1.Now(),
+	}
+	switch vmi.Status.Phase {
+	case virtv1.Succeeded:
+		result.Phase = v1alpha1.SandboxResultPhaseSucceeded
+	case virtv1.Failed:
+		result.Phase = v1alpha1.SandboxResultPhaseFailed
+	default:
+		return nil, nil
+	}
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		if transition.Phase == vmi.Status.Phase {
+			result.FinishedAt = transition.PhaseTransitionTimestamp
+		}
+	}
+	var msgs []string
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Message != "" {
+			msgs = append(msgs, condition.Message)
+		}
+	}
+	result.Message = strings.Join(msgs, "\n")
+
+	return result, nil
+}
+
+// injectSSHKeys merges the keys into the cloudInitNoCloud user data of the vmi.
+// The merged user data is stored in the per-sandbox secret, the vmi refers to it.
+func (p KubevirtSandboxer) injectSSHKeys(ctx context.Context, sandbox *v1alpha1.Sandbox, vmi *virtv1.VirtualMachineInstance, keys []string) error {
+	const cloudInitVolumeName = "cloudinitdisk"
+
+	var source *virtv1.CloudInitNoCloudSource
+	for i, volume := range vmi.Spec.Volumes {
+		if volume.CloudInitConfigDrive != nil {
+			return fmt.Errorf("ssh keys injection is not supported for cloudInitConfigDrive volume %q", volume.Name)
+		}
+		if volume.CloudInitNoCloud != nil {
+			source = vmi.Spec.Volumes[i].CloudInitNoCloud
+		}
+	}
+
+	var userData string
+	if source != nil {
+		switch {
+		case source.UserDataSecretRef != nil:
+			data, err := p.cloudInitManager.getSecretUserData(ctx, sandbox.GetNamespace(), source.UserDataSecretRef.Name, "userdata", "userData")
+			if err != nil {
+				return err
+			}
+			userData = data
+		case source.UserDataBase64 != "":
+			data, err := base64.StdEncoding.DecodeString(source.UserDataBase64)
+			if err != nil {
+				return fmt.Errorf("failed to decode cloud-init user data: %w", err)
+			}
+			userData = string(data)
+		default:
+			userData = source.UserData
+		}
+	} else {
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, virtv1.Volume{
+			Name: cloudInitVolumeName,
+			VolumeSource: virtv1.VolumeSource{
+				CloudInitNoCloud: &virtv1.CloudInitNoCloudSource{},
+			},
+		})
+		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, virtv1.Disk{
+			Name: cloudInitVolumeName,
+			DiskDevice: virtv1.DiskDevice{
+				Disk: &virtv1.DiskTarget{
+					Bus: virtv1.DiskBusVirtio,
+				},
+			},
+		})
+		source = vmi.Spec.Volumes[len(vmi.Spec.Volumes)-1].CloudInitNoCloud
+	}
+
+	userData, err := mergeSSHKeys(userData, keys)
+	if err != nil {
+		return err
+	}
+	if err = p.cloudInitManager.createSecret(ctx, sandbox, corev1.SecretTypeOpaque, userData); err != nil {
+		return err
+	}
+
+	source.UserData = ""
+	source.UserDataBase64 = ""
+	source.UserDataSecretRef = &corev1.LocalObjectReference{Name: getFullCloudInitSecretName(sandbox)}
+
+	return nil
+}
+
 func (p KubevirtSandboxer) getVMI(ctx context.Context, sandbox *v1alpha1.Sandbox) (*virtv1.VirtualMachineInstance, error) {
 	vmi := &virtv1.VirtualMachineInstance{}
-	err := p.client.Get(ctx, client.ObjectKey{Namespace: sandbox.GetNamespace(), Name: common.GetFullName(sandbox)}, vmi)
+	err := p.client.Get(ctx, client.ObjectKey{Namespace: common.GetChildNamespace(sandbox), Name: common.GetFullName(sandbox)}, vmi)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -161,16 +281,13 @@ func (p KubevirtSandboxer) getVMI(ctx context.Context, sandbox *v1alpha1.Sandbox
 
 func (p KubevirtSandboxer) getDVs(ctx context.Context, sandbox *v1alpha1.Sandbox) ([]*cdiv1beta1.DataVolume, error) {
 	dvs := &cdiv1beta1.DataVolumeList{}
-	err := p.client.List(ctx, dvs, client.InNamespace(sandbox.GetNamespace()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to list VirtualDisks %w", err)
+	if err := listChildren(ctx, p.client, sandbox, dvs); err != nil {
+		return nil, fmt.Errorf("failed to list DataVolumes %w", err)
 	}
 
-	var result []*cdiv1beta1.DataVolume
-	for _, dv := range dvs.Items {
-		if metav1.IsControlledBy(&dv, sandbox) {
-			result = append(result, &dv)
-		}
+	result := make([]*cdiv1beta1.DataVolume, 0, len(dvs.Items))
+	for i := range dvs.Items {
+		result = append(result, &dvs.Items[i])
 	}
 
 	return result, nil
@@ -193,14 +310,10 @@ func newKubevirtVMI(sandbox *v1alpha1.Sandbox, spec virtv1.VirtualMachineInstanc
 			APIVersion: virtv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.GetFullName(sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            common.GetFullName(sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -213,14 +326,10 @@ func newDataVolume(sandbox *v1alpha1.Sandbox, name string, spec cdiv1beta1.DataV
 			APIVersion: cdiv1beta1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getFullDVName(name, sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            getFullDVName(name, sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -270,7 +379,7 @@ func mutateKubevirtVMIVolumes(sandbox *v1alpha1.Sandbox, vmi *virtv1.VirtualMach
 const dvNamePrefix = "sandbox-dv-"
 
 func getFullDVName(name string, sandbox *v1alpha1.Sandbox) string {
-	return fmt.Sprintf("%s%s-%s", dvNamePrefix, sandbox.GetUID(), name)
+	return fmt.Sprintf("%s%s-%s", dvNamePrefix, common.GetID(sandbox), name)
 }
 
 func getReasonFromKubevirtVMIPhase(phase virtv1.VirtualMachineInstancePhase) sandboxcondition.Reason {
@@ -0,0 +1,119 @@
+package sandbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/condition"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+const (
+	metricsNamespace      = "sandbox"
+	metricsCollectTimeout = 10 * time.Second
+)
+
+var (
+	timeToReady = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "time_to_ready_seconds",
+		Help:      "Time from the sandbox creation to the Ready condition.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+	}, []string{"type"})
+	ttlExpirations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "ttl_expirations_total",
+		Help:      "Total number of the sandboxes deleted by TTL.",
+	}, []string{"type"})
+	createFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "create_failures_total",
+		Help:      "Total number of the failed attempts to create the sandbox resources.",
+	}, []string{"type"})
+	restarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "restarts_total",
+		Help:      "Total number of the restarts of the failed sandbox workloads.",
+	}, []string{"type"})
+
+	sandboxesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "sandboxes"),
+		"Number of the sandboxes by type, template, namespace and reason of the Ready condition.",
+		[]string{"type", "template", "namespace", "reason"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(timeToReady, ttlExpirations, createFailures, restarts)
+}
+
+// sandboxCollector counts the sandboxes in the cache on every scrape.
+type sandboxCollector struct {
+	reader client.Reader
+	log    *slog.Logger
+}
+
+func registerSandboxCollector(reader client.Reader, log *slog.Logger) error {
+	return metrics.Registry.Register(&sandboxCollector{reader: reader, log: log})
+}
+
+func (c *sandboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sandboxesDesc
+}
+
+func (c *sandboxCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsCollectTimeout)
+	defer cancel()
+
+	sandboxes := &v1alpha1.SandboxList{}
+	if err := c.reader.List(ctx, sandboxes); err != nil {
+		c.log.Error("Failed to list sandboxes for metrics", logging.SlogErr(err))
+		return
+	}
+
+	type key struct {
+		sandboxType v1alpha1.SandboxType
+		template    string
+		namespace   string
+		reason      string
+	}
+	counts := make(map[key]int)
+	for _, sandbox := range sandboxes.Items {
+		reason := ""
+		if cond, found := condition.GetCondition(sandboxcondition.TypeReady, sandbox.Status.Conditions); found {
+			reason = cond.Reason
+		}
+		counts[key{
+			sandboxType: sandbox.Status.Type,
+			template:    sandbox.Spec.Template,
+			namespace:   sandbox.GetNamespace(),
+			reason:      reason,
+		}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(sandboxesDesc, prometheus.GaugeValue, float64(count),
+			string(k.sandboxType), k.template, k.namespace, k.reason)
+	}
+}
+
+func isReady(sandbox *v1alpha1.Sandbox) bool {
+	cond, found := condition.GetCondition(sandboxcondition.TypeReady, sandbox.Status.Conditions)
+	return found && cond.Status == metav1.ConditionTrue
+}
+
+// observeReady records the time to Ready when the sandbox becomes ready.
+func observeReady(sandbox *v1alpha1.Sandbox, wasReady bool) {
+	if wasReady || !isReady(sandbox) {
+		return
+	}
+	timeToReady.WithLabelValues(string(sandbox.Status.Type)).Observe(time.Since(sandbox.GetCreationTimestamp().Time).Seconds())
+}
@@ -0,0 +1,182 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+)
+
+const (
+	dedicatedResourceQuotaName = "sandbox-quota"
+	dedicatedLimitRangeName    = "sandbox-limits"
+	dedicatedRoleBindingName   = "sandbox-owner"
+	defaultOwnerClusterRole    = "edit"
+)
+
+func isDedicatedNamespace(templateSpec *v1alpha1.SandboxTemplateSpec) bool {
+	return templateSpec != nil && templateSpec.Namespace == v1alpha1.NamespaceModeDedicated
+}
+
+func getDedicatedNamespaceName(sandbox *v1alpha1.Sandbox) string {
+	return common.NamePrefix + string(sandbox.GetUID())
+}
+
+// inDedicatedNamespace reports whether the child resources live outside the sandbox namespace.
+func inDedicatedNamespace(sandbox *v1alpha1.Sandbox) bool {
+	return common.GetChildNamespace(sandbox) != sandbox.GetNamespace()
+}
+
+func newChildLabels(sandbox *v1alpha1.Sandbox) map[string]string {
+	labels := map[string]string{
+		v1alpha1.LabelSandboxUID: string(sandbox.GetUID()),
+	}
+	if component := common.GetComponent(sandbox); component != "" {
+		labels[labelSandboxComponent] = component
+	}
+	if inDedicatedNamespace(sandbox) {
+		labels[labelSandboxName] = sandbox.GetName()
+		labels[labelSandboxNamespace] = sandbox.GetNamespace()
+	}
+	return labels
+}
+
+// newChildOwnerReferences returns the controller reference to the sandbox.
+// Owner references cannot span namespaces, so the children in the dedicated namespace are tracked by labels.
+func newChildOwnerReferences(sandbox *v1alpha1.Sandbox) []metav1.OwnerReference {
+	if inDedicatedNamespace(sandbox) {
+		return nil
+	}
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
+	}
+}
+
+type namespaceManager struct {
+	client client.Client
+}
+
+// ensureNamespace creates the dedicated namespace with the quota, the limits and the role binding for the sandbox owner.
+// The namespace is protected by the finalizer, it is released in deleteNamespace.
+func (m namespaceManager) ensureNamespace(ctx context.Context, sandbox *v1alpha1.Sandbox, spec *v1alpha1.DedicatedNamespaceSpec) error {
+	name := getDedicatedNamespaceName(sandbox)
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				v1alpha1.LabelSandboxUID: string(sandbox.GetUID()),
+				labelSandboxName:         sandbox.GetName(),
+				labelSandboxNamespace:    sandbox.GetNamespace(),
+			},
+			Finalizers: []string{v1alpha1.FinalizerProtectBySandboxController},
+		},
+	}
+	if err := m.create(ctx, namespace); err != nil {
+		return err
+	}
+
+	if spec == nil {
+		spec = &v1alpha1.DedicatedNamespaceSpec{}
+	}
+	if spec.ResourceQuota != nil {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: m.newObjectMeta(sandbox, dedicatedResourceQuotaName),
+			Spec:       *spec.ResourceQuota,
+		}
+		if err := m.create(ctx, quota); err != nil {
+			return err
+		}
+	}
+	if spec.LimitRange != nil {
+		limitRange := &corev1.LimitRange{
+			ObjectMeta: m.newObjectMeta(sandbox, dedicatedLimitRangeName),
+			Spec:       *spec.LimitRange,
+		}
+		if err := m.create(ctx, limitRange); err != nil {
+			return err
+		}
+	}
+	if owner := sandbox.GetAnnotations()[v1alpha1.AnnotationSandboxOwner]; owner != "" {
+		clusterRole := spec.OwnerClusterRole
+		if clusterRole == "" {
+			clusterRole = defaultOwnerClusterRole
+		}
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: m.newObjectMeta(sandbox, dedicatedRoleBindingName),
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     clusterRole,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.UserKind,
+					Name:     owner,
+				},
+			},
+		}
+		if err := m.create(ctx, roleBinding); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteNamespace deletes the dedicated namespace and releases it, the child resources are deleted with it.
+func (m namespaceManager) deleteNamespace(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	namespace := &corev1.Namespace{}
+	err := m.client.Get(ctx, client.ObjectKey{Name: getDedicatedNamespaceName(sandbox)}, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get namespace %w", err)
+	}
+	if namespace.Labels[v1alpha1.LabelSandboxUID] != string(sandbox.GetUID()) {
+		return fmt.Errorf("namespace %q does not belong to the sandbox", namespace.Name)
+	}
+
+	if namespace.GetDeletionTimestamp().IsZero() {
+		if err = m.client.Delete(ctx, namespace); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete namespace %q", namespace.Name)
+		}
+	}
+	if controllerutil.RemoveFinalizer(namespace, v1alpha1.FinalizerProtectBySandboxController) {
+		if err = m.client.Update(ctx, namespace); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to release namespace %q: %w", namespace.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m namespaceManager) newObjectMeta(sandbox *v1alpha1.Sandbox, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: getDedicatedNamespaceName(sandbox),
+		Labels: map[string]string{
+			v1alpha1.LabelSandboxUID: string(sandbox.GetUID()),
+		},
+	}
+}
+
+func (m namespaceManager) create(ctx context.Context, obj client.Object) error {
+	err := m.client.Create(ctx, obj)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create %T %q: %w", obj, client.ObjectKeyFromObject(obj).String(), err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	sandboxbackendcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandboxbackend-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/condition"
+	pluginv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/pkg/plugin/v1alpha1"
+)
+
+const (
+	// pluginSyncPeriod is the period of the status polling, the plugin resources are not watched.
+	pluginSyncPeriod     = 10 * time.Second
+	pluginDefaultTimeout = 30 * time.Second
+)
+
+var pluginConns = &pluginConnections{conns: make(map[string]*pluginConnection)}
+
+// pluginConnections caches the connections to the plugin servers by the name of the SandboxBackend.
+type pluginConnections struct {
+	mu    sync.Mutex
+	conns map[string]*pluginConnection
+}
+
+type pluginConnection struct {
+	// key identifies the endpoint and the TLS settings of the connection, the connection is replaced when it changes.
+	key  string
+	conn *grpc.ClientConn
+}
+
+func (c *pluginConnections) get(name, key string, dial func() (*grpc.ClientConn, error)) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pc, ok := c.conns[name]; ok {
+		if pc.key == key {
+			return pc.conn, nil
+		}
+		_ = pc.conn.Close()
+		delete(c.conns, name)
+	}
+	conn, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	c.conns[name] = &pluginConnection{key: key, conn: conn}
+	return conn, nil
+}
+
+func (c *pluginConnections) close(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pc, ok := c.conns[name]
+	if !ok {
+		return nil
+	}
+	delete(c.conns, name)
+	return pc.conn.Close()
+}
+
+// NewPluginBackendClient returns the client of the plugin server described by the SandboxBackend.
+// The connection is reused until the endpoint or the TLS settings of the SandboxBackend change.
+func NewPluginBackendClient(ctx context.Context, c client.Client, backend *v1alpha1.SandboxBackend) (pluginv1alpha1.BackendClient, time.Duration, error) {
+	creds, key, err := pluginTransportCredentials(ctx, c, backend)
+	if err != nil {
+		return nil, 0, err
+	}
+	conn, err := pluginConns.get(backend.Name, key, func() (*grpc.ClientConn, error) {
+		conn, err := grpc.NewClient(backend.Spec.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to plugin %q: %w", backend.Spec.Endpoint, err)
+		}
+		return conn, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	timeout := backend.Spec.Timeout.Duration
+	if timeout == 0 {
+		timeout = pluginDefaultTimeout
+	}
+	return pluginv1alpha1.NewBackendClient(conn), timeout, nil
+}
+
+// ClosePluginBackendClient closes the connection to the plugin server of the deleted SandboxBackend.
+func ClosePluginBackendClient(name string) error {
+	return pluginConns.close(name)
+}
+
+func NewPluginSandboxer(backend string, client client.Client, log *slog.Logger) *PluginSandboxer {
+	return &PluginSandboxer{
+		backend: backend,
+		client:  client,
+		log:     log,
+	}
+}
+
+type PluginSandboxer struct {
+	backend string
+	client  client.Client
+	log     *slog.Logger
+}
+
+func (p PluginSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) error {
+	if templateSpec.PluginSpec == nil {
+		return nil
+	}
+
+	backendClient, timeout, err := p.getBackendClient(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = backendClient.Create(ctx, &pluginv1alpha1.CreateRequest{
+		Sandbox: sandbox,
+		Spec:    templateSpec.PluginSpec.Spec.Raw,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create sandbox by plugin %q: %w", p.backend, err)
+	}
+	return nil
+}
+
+func (p PluginSandboxer) Delete(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	backendClient, timeout, err := p.getBackendClient(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			p.log.Warn("Cannot get sandbox backend, that's why plugin resources should be deleted manually", slog.String("backend", p.backend))
+			return nil
+		}
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = backendClient.Delete(ctx, &pluginv1alpha1.DeleteRequest{
+		Sandbox: sandbox,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sandbox by plugin %q: %w", p.backend, err)
+	}
+	return nil
+}
+
+func (p PluginSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (metav1.ConditionStatus, sandboxcondition.Reason, string, error) {
+	templateSpec, err := getTemplateSpec(ctx, p.client, sandbox)
+	if err != nil || templateSpec == nil || templateSpec.PluginSpec == nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+
+	backendClient, timeout, err := p.getBackendClient(ctx)
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := backendClient.Status(ctx, &pluginv1alpha1.StatusRequest{
+		Sandbox: sandbox,
+		Spec:    templateSpec.PluginSpec.Spec.Raw,
+	})
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", fmt.Errorf("failed to get sandbox status from plugin %q: %w", p.backend, err)
+	}
+
+	return resp.Status, sandboxcondition.Reason(resp.Reason), resp.Message, nil
+}
+
+func (p PluginSandboxer) getBackendClient(ctx context.Context) (pluginv1alpha1.BackendClient, time.Duration, error) {
+	backend := &v1alpha1.SandboxBackend{}
+	err := p.client.Get(ctx, client.ObjectKey{Name: p.backend}, backend)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sandbox backend %q: %w", p.backend, err)
+	}
+	// The plugin server is dialed only after the SandboxBackend controller has probed its current spec.
+	ready, _ := condition.GetCondition(sandboxbackendcondition.TypeReady, backend.Status.Conditions)
+	if ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != backend.Generation {
+		return nil, 0, fmt.Errorf("sandbox backend %q is not ready: %s", p.backend, ready.Message)
+	}
+	return NewPluginBackendClient(ctx, p.client, backend)
+}
@@ -0,0 +1,77 @@
+package sandbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+// pluginCAKey is the key of the CA bundle in the TLS secret of the SandboxBackend.
+const pluginCAKey = "ca.crt"
+
+// pluginTransportCredentials returns the transport credentials of the connection to the plugin server
+// and the key, which changes with the endpoint and the TLS settings of the SandboxBackend.
+// The connection uses TLS with the system roots, unless the SandboxBackend opts in to the insecure connection.
+func pluginTransportCredentials(ctx context.Context, c client.Client, backend *v1alpha1.SandboxBackend) (credentials.TransportCredentials, string, error) {
+	h := sha256.New()
+	write := func(values ...[]byte) {
+		for _, v := range values {
+			_, _ = h.Write(v)
+			_, _ = h.Write([]byte{0})
+		}
+	}
+	write([]byte(backend.Spec.Endpoint))
+
+	spec := backend.Spec.TLS
+	if spec == nil {
+		spec = &v1alpha1.SandboxBackendTLS{}
+	}
+	if spec.Insecure {
+		write([]byte("insecure"))
+		return insecure.NewCredentials(), hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	caBundle := spec.CABundle
+	var certificates []tls.Certificate
+	if ref := spec.SecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, "", fmt.Errorf("failed to get TLS secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		caBundle = append(append([]byte{}, caBundle...), secret.Data[pluginCAKey]...)
+
+		cert, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		if len(cert) > 0 || len(key) > 0 {
+			certificate, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid client certificate in secret %s/%s: %w", ref.Namespace, ref.Name, err)
+			}
+			certificates = append(certificates, certificate)
+		}
+		write(cert, key)
+	}
+	write(caBundle, []byte(spec.ServerName))
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		ServerName:   spec.ServerName,
+		Certificates: certificates,
+	}
+	if len(caBundle) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, "", fmt.Errorf("no certificates found in the CA bundle of sandbox backend %q", backend.Name)
+		}
+	}
+	return credentials.NewTLS(config), hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -36,6 +36,12 @@ func (p PodSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, tem
 	if err := p.pvcManager.createPVCs(ctx, sandbox, pvcsForCreate); err != nil {
 		return err
 	}
+	artifacts := getArtifacts(templateSpec)
+	if artifacts != nil {
+		if err := p.pvcManager.createArtifactsPVC(ctx, sandbox, artifacts); err != nil {
+			return err
+		}
+	}
 
 	pod, err := p.getPOD(ctx, sandbox)
 	if err != nil {
@@ -43,15 +49,25 @@ func (p PodSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, tem
 	}
 	if pod != nil {
 		reason := getReasonFromPodPhase(pod.Status.Phase)
-		if reason != sandboxcondition.ReasonFailed {
+		if reason != sandboxcondition.ReasonFailed || isCompletion(templateSpec) {
 			return nil
 		}
 
-		return p.client.Delete(ctx, pod)
+		if err = p.client.Delete(ctx, pod); err != nil {
+			return fmt.Errorf("failed to restart pod %q", client.ObjectKeyFromObject(pod).String())
+		}
+		restarts.WithLabelValues(string(v1alpha1.SandboxTypePod)).Inc()
+		return nil
 	}
 	if templateSpec.PodSpec != nil {
 		pod = newPod(sandbox, *templateSpec.PodSpec)
 		mutatePodPVCs(sandbox, pod, pvcsForCreate)
+		if isCompletion(templateSpec) {
+			pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+		}
+		if artifacts != nil {
+			mutatePodArtifacts(sandbox, pod, artifacts)
+		}
 		if err = p.client.Create(ctx, pod); err != nil {
 			return fmt.Errorf("failed to create pod %q", client.ObjectKeyFromObject(pod).String())
 		}
@@ -98,9 +114,51 @@ func (p PodSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (me
 
 }
 
+func (p PodSandboxer) Result(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) (*v1alpha1.SandboxResult, error) {
+	pod, err := p.getPOD(ctx, sandbox)
+	if err != nil || pod == nil {
+		return nil, err
+	}
+
+	result := &v1alpha1.SandboxResult{}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		result.Phase = v1alpha1.SandboxResultPhaseSucceeded
+	case corev1.PodFailed:
+		result.Phase = v1alpha1.SandboxResultPhaseFailed
+		result.Message = pod.Status.Message
+	default:
+		return nil, nil
+	}
+
+	if len(pod.Spec.Containers) > 0 {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != pod.Spec.Containers[0].Name || status.State.Terminated == nil {
+				continue
+			}
+			terminated := status.State.Terminated
+			result.ExitCode = &terminated.ExitCode
+			result.FinishedAt = terminated.FinishedAt
+			if terminated.Message != "" {
+				result.Message = terminated.Message
+			} else if result.Message == "" {
+				result.Message = terminated.Reason
+			}
+		}
+	}
+	if result.FinishedAt.IsZero() {
+		result.FinishedAt = metav1.Now()
+	}
+	if getArtifacts(templateSpec) != nil {
+		result.ArtifactsClaimName = getFullArtifactsPVCName(sandbox)
+	}
+
+	return result, nil
+}
+
 func (p PodSandboxer) getPOD(ctx context.Context, sandbox *v1alpha1.Sandbox) (*corev1.Pod, error) {
 	pod := &corev1.Pod{}
-	err := p.client.Get(ctx, client.ObjectKey{Namespace: sandbox.GetNamespace(), Name: common.GetFullName(sandbox)}, pod)
+	err := p.client.Get(ctx, client.ObjectKey{Namespace: common.GetChildNamespace(sandbox), Name: common.GetFullName(sandbox)}, pod)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -133,14 +191,10 @@ func newPod(sandbox *v1alpha1.Sandbox, spec corev1.PodSpec) *corev1.Pod {
 			APIVersion: corev1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.GetFullName(sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            common.GetFullName(sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
 )
 
 func makePersistentVolumeClaims(sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) []*corev1.PersistentVolumeClaim {
@@ -28,14 +29,10 @@ func newPVC(sandbox *v1alpha1.Sandbox, name string, spec corev1.PersistentVolume
 			APIVersion: corev1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getFullPVCName(name, sandbox),
-			Namespace: sandbox.GetNamespace(),
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(sandbox, v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.SandboxKind)),
-			},
-			Labels: map[string]string{
-				labelSandboxUID: string(sandbox.GetUID()),
-			},
+			Name:            getFullPVCName(name, sandbox),
+			Namespace:       common.GetChildNamespace(sandbox),
+			OwnerReferences: newChildOwnerReferences(sandbox),
+			Labels:          newChildLabels(sandbox),
 		},
 		Spec: spec,
 	}
@@ -44,24 +41,7 @@ func newPVC(sandbox *v1alpha1.Sandbox, name string, spec corev1.PersistentVolume
 const pvcNamePrefix = "sandbox-pvc-"
 
 func getFullPVCName(name string, sandbox *v1alpha1.Sandbox) string {
-	return fmt.Sprintf("%s%s-%s", pvcNamePrefix, sandbox.GetUID(), name)
-}
-
-func getPVCs(ctx context.Context, sandbox *v1alpha1.Sandbox, c client.Client) ([]*corev1.PersistentVolumeClaim, error) {
-	pvcs := &corev1.PersistentVolumeClaimList{}
-	err := c.List(ctx, pvcs, client.InNamespace(sandbox.GetNamespace()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to list PVCs %w", err)
-	}
-
-	var result []*corev1.PersistentVolumeClaim
-	for _, pvc := range pvcs.Items {
-		if metav1.IsControlledBy(&pvc, sandbox) {
-			result = append(result, &pvc)
-		}
-	}
-
-	return result, nil
+	return fmt.Sprintf("%s%s-%s", pvcNamePrefix, common.GetID(sandbox), name)
 }
 
 type pvcManager struct {
@@ -104,16 +84,13 @@ func (m pvcManager) deletePVCs(ctx context.Context, sandbox *v1alpha1.Sandbox) e
 
 func (m pvcManager) getPVCs(ctx context.Context, sandbox *v1alpha1.Sandbox) ([]*corev1.PersistentVolumeClaim, error) {
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err := m.client.List(ctx, pvcs, client.InNamespace(sandbox.GetNamespace()))
-	if err != nil {
+	if err := listChildren(ctx, m.client, sandbox, pvcs); err != nil {
 		return nil, fmt.Errorf("failed to list PVCs %w", err)
 	}
 
-	var result []*corev1.PersistentVolumeClaim
-	for _, pvc := range pvcs.Items {
-		if metav1.IsControlledBy(&pvc, sandbox) {
-			result = append(result, &pvc)
-		}
+	result := make([]*corev1.PersistentVolumeClaim, 0, len(pvcs.Items))
+	for i := range pvcs.Items {
+		result = append(result, &pvcs.Items[i])
 	}
 
 	return result, nil
@@ -1,6 +1,7 @@
 package sandbox
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
@@ -13,13 +14,21 @@ import (
 )
 
 const (
-	controllerName  = "sandbox-controller"
-	labelSandboxUID = "sandbox.io/uid"
+	controllerName        = "sandbox-controller"
+	labelSandboxName      = "sandbox.io/name"
+	labelSandboxNamespace = "sandbox.io/namespace"
+	labelSandboxComponent = "sandbox.io/component"
 )
 
-func SetupController(mgr ctrl.Manager, log *slog.Logger) error {
+func SetupController(ctx context.Context, mgr ctrl.Manager, log *slog.Logger) error {
 	log = log.With(logging.SlogController(controllerName))
+	if err := setupIndexers(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to setup indexers of %q: %w", controllerName, err)
+	}
 	c := mgr.GetClient()
+	if err := registerSandboxCollector(mgr.GetCache(), log); err != nil {
+		return fmt.Errorf("failed to register metrics of %q: %w", controllerName, err)
+	}
 	r := reconciler.NewBaseReconciler(v1alpha1.SandboxKind, c,
 		func() *v1alpha1.Sandbox {
 			return &v1alpha1.Sandbox{}
@@ -14,12 +14,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	virtv1 "kubevirt.io/api/core/v1"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -35,16 +37,18 @@ import (
 
 func NewReconciler(client client.Client, recorder record.EventRecorder, managerCreator SandboxerCreator) *Reconciler {
 	return &Reconciler{
-		client:         client,
-		recorder:       recorder,
-		managerCreator: managerCreator,
+		client:           client,
+		recorder:         recorder,
+		managerCreator:   managerCreator,
+		namespaceManager: namespaceManager{client: client},
 	}
 }
 
 type Reconciler struct {
-	client         client.Client
-	recorder       record.EventRecorder
-	managerCreator SandboxerCreator
+	client           client.Client
+	recorder         record.EventRecorder
+	managerCreator   SandboxerCreator
+	namespaceManager namespaceManager
 }
 
 type SandboxerCreator func(sandboxType v1alpha1.SandboxType, client client.Client, log *slog.Logger) Sandboxer
@@ -55,6 +59,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, sandbox *v1alpha1.Sandbox) (
 	}
 
 	log := logging.FromContext(ctx)
+	wasReady := isReady(sandbox)
 
 	cb := condition.NewConditionBuilder(sandboxcondition.TypeReady)
 	cb.Generation(sandbox.Generation).
@@ -83,9 +88,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, sandbox *v1alpha1.Sandbox) (
 
 	if isTTLExpired(sandbox) {
 		log.Info("Sandbox is expired, deleting...")
+		ttlExpirations.WithLabelValues(string(sandbox.Status.Type)).Inc()
 		return reconcile.Result{}, r.client.Delete(ctx, sandbox)
 	}
 
+	if sandbox.Status.Result != nil {
+		setResultCondition(cb, sandbox.Status.Result)
+		condition.SetCondition(cb, &sandbox.Status.Conditions)
+		return reconcile.Result{RequeueAfter: nextSync(sandbox)}, nil
+	}
+
 	if templateTerminating || sandboxTemplateSpec == nil || sandboxer == nil {
 		return reconcile.Result{}, nil
 	}
@@ -95,8 +107,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, sandbox *v1alpha1.Sandbox) (
 		return reconcile.Result{}, fmt.Errorf("failed to protect sandbox template: %w", err)
 	}
 
+	if isDedicatedNamespace(sandboxTemplateSpec) {
+		sandbox.Status.Namespace = getDedicatedNamespaceName(sandbox)
+		if err := r.namespaceManager.ensureNamespace(ctx, sandbox, sandboxTemplateSpec.DedicatedNamespace); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to create dedicated namespace: %w", err)
+		}
+	}
+
 	if err := sandboxer.Create(ctx, sandbox, sandboxTemplateSpec); err != nil {
 		log.Error("Failed to create sandbox", logging.SlogErr(err))
+		createFailures.WithLabelValues(string(sandbox.Status.Type)).Inc()
 		cb.
 			Status(metav1.ConditionFalse).
 			Reason(sandboxcondition.ReasonFailed).
@@ -110,13 +130,76 @@ func (r *Reconciler) Reconcile(ctx context.Context, sandbox *v1alpha1.Sandbox) (
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to get sandbox status: %w", err)
 	}
+
+	volumes, err := getVolumeStatuses(ctx, r.client, sandbox, sandboxTemplateSpec)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get volumes status: %w", err)
+	}
+	sandbox.Status.Volumes = nil
+	for _, volume := range volumes {
+		sandbox.Status.Volumes = append(sandbox.Status.Volumes, volume.SandboxVolumeStatus)
+	}
+	if reason == sandboxcondition.ReasonPending {
+		if stuckMessage := getStuckVolumeMessage(volumes); stuckMessage != "" {
+			message = stuckMessage
+		}
+	}
 	cb.
 		Status(status).
 		Reason(reason).
 		Message(message)
 	condition.SetCondition(cb, &sandbox.Status.Conditions)
+	observeReady(sandbox, wasReady)
+
+	if completer, ok := sandboxer.(Completer); ok && isCompletion(sandboxTemplateSpec) {
+		result, err := completer.Result(ctx, sandbox, sandboxTemplateSpec)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get sandbox result: %w", err)
+		}
+		if result != nil {
+			return reconcile.Result{RequeueAfter: nextSync(sandbox)}, r.handleFinished(ctx, sandbox, result, sandboxer, cb, log)
+		}
+	}
 
-	return reconcile.Result{RequeueAfter: nextSync(sandbox)}, nil
+	requeueAfter := nextSync(sandbox)
+	if syncPeriod := getSyncPeriod(sandbox.Status.Type); syncPeriod != 0 && (requeueAfter == 0 || requeueAfter > syncPeriod) {
+		requeueAfter = syncPeriod
+	}
+	// The volume can get stuck without any events, so it is checked again later.
+	if !areVolumesReady(volumes) && (requeueAfter == 0 || requeueAfter > volumeSyncPeriod) {
+		requeueAfter = volumeSyncPeriod
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// handleFinished records the result of the finished sandbox and tears down the workload,
+// the artifacts claim is retained.
+func (r *Reconciler) handleFinished(ctx context.Context, sandbox *v1alpha1.Sandbox, result *v1alpha1.SandboxResult, sandboxer Sandboxer, cb *condition.ConditionBuilder, log *slog.Logger) error {
+	log.Info("Sandbox is finished", slog.String("phase", string(result.Phase)))
+
+	sandbox.Status.Result = result
+	setResultCondition(cb, result)
+	condition.SetCondition(cb, &sandbox.Status.Conditions)
+
+	eventType := corev1.EventTypeNormal
+	if result.Phase == v1alpha1.SandboxResultPhaseFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Eventf(sandbox, eventType, string(result.Phase), "Sandbox is finished: %s", result.Message)
+
+	return sandboxer.Delete(ctx, sandbox)
+}
+
+func setResultCondition(cb *condition.ConditionBuilder, result *v1alpha1.SandboxResult) {
+	reason := sandboxcondition.ReasonSucceeded
+	if result.Phase == v1alpha1.SandboxResultPhaseFailed {
+		reason = sandboxcondition.ReasonFailed
+	}
+	cb.
+		Status(metav1.ConditionFalse).
+		Reason(reason).
+		Message(result.Message)
 }
 
 func (r *Reconciler) handleTemplateSpec(ctx context.Context, sandbox *v1alpha1.Sandbox, cb *condition.ConditionBuilder, log *slog.Logger) (*v1alpha1.SandboxTemplate, *v1alpha1.SandboxTemplateSpec, bool, error) {
@@ -178,6 +261,12 @@ func (r *Reconciler) handleTerminating(ctx context.Context, sandbox *v1alpha1.Sa
 		log.Warn("Cannot detect sandbox type, that's why some child resources can be deleted in background")
 	}
 
+	if sandbox.Status.Namespace != "" {
+		if err := r.namespaceManager.deleteNamespace(ctx, sandbox); err != nil {
+			return fmt.Errorf("failed to delete dedicated namespace: %w", err)
+		}
+	}
+
 	if sandboxTemplate != nil {
 		if err := scontrollerutil.UnprotectObject(ctx, r.client, v1alpha1.FinalizerProtectBySandboxController, sandboxTemplate); err != nil {
 			return fmt.Errorf("failed to unprotect sandbox template: %w", err)
@@ -192,43 +281,104 @@ func (r *Reconciler) Setup(reconciler reconcile.Reconciler, mgr ctrl.Manager, lo
 	b := ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Sandbox{}).
-		Owns(&corev1.Pod{}, builder.WithPredicates(predicate.Funcs{
-			UpdateFunc: func(e event.UpdateEvent) bool {
-				oldPod := e.ObjectOld.(*corev1.Pod)
-				newPod := e.ObjectNew.(*corev1.Pod)
-
-				return oldPod.Status.Phase != newPod.Status.Phase
-			},
-		})).
+		Owns(&corev1.Pod{}, builder.WithPredicates(podPredicate)).
+		Watches(&corev1.Pod{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, podPredicate)).
+		Owns(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(pvcPredicate)).
+		Watches(&corev1.PersistentVolumeClaim{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, pvcPredicate)).
 		WithOptions(controller.Options{
 			RecoverPanic:   ptr.To(true),
 			LogConstructor: logging.NewConstructor(log),
 		})
 	if featuregate.Enabled(featuregate.Kubevirt) {
 		b = b.
-			Owns(&virtv1.VirtualMachineInstance{}, builder.WithPredicates(predicate.Funcs{
-				UpdateFunc: func(e event.UpdateEvent) bool {
-					oldVMI := e.ObjectOld.(*virtv1.VirtualMachineInstance)
-					newVMI := e.ObjectNew.(*virtv1.VirtualMachineInstance)
-
-					return oldVMI.Status.Phase != newVMI.Status.Phase
-				},
-			}))
+			Owns(&virtv1.VirtualMachineInstance{}, builder.WithPredicates(vmiPredicate)).
+			Watches(&virtv1.VirtualMachineInstance{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, vmiPredicate)).
+			Owns(&cdiv1beta1.DataVolume{}, builder.WithPredicates(dvPredicate)).
+			Watches(&cdiv1beta1.DataVolume{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, dvPredicate))
 	}
 	if featuregate.Enabled(featuregate.DVP) {
 		b = b.
-			Owns(&dvpcorev1alpha2.VirtualMachine{}, builder.WithPredicates(predicate.Funcs{
-				UpdateFunc: func(e event.UpdateEvent) bool {
-					oldVM := e.ObjectOld.(*dvpcorev1alpha2.VirtualMachine)
-					newVM := e.ObjectNew.(*dvpcorev1alpha2.VirtualMachine)
-
-					return oldVM.Status.Phase != newVM.Status.Phase
-				},
-			}))
+			Owns(&dvpcorev1alpha2.VirtualMachine{}, builder.WithPredicates(vmPredicate)).
+			Watches(&dvpcorev1alpha2.VirtualMachine{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, vmPredicate)).
+			Owns(&dvpcorev1alpha2.VirtualDisk{}, builder.WithPredicates(vdPredicate)).
+			Watches(&dvpcorev1alpha2.VirtualDisk{}, enqueueSandboxByLabels(), builder.WithPredicates(dedicatedPredicate, vdPredicate))
 	}
 	return b.Complete(reconciler)
 }
 
+var (
+	podPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod := e.ObjectOld.(*corev1.Pod)
+			newPod := e.ObjectNew.(*corev1.Pod)
+
+			return oldPod.Status.Phase != newPod.Status.Phase
+		},
+	}
+	vmiPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVMI := e.ObjectOld.(*virtv1.VirtualMachineInstance)
+			newVMI := e.ObjectNew.(*virtv1.VirtualMachineInstance)
+
+			return oldVMI.Status.Phase != newVMI.Status.Phase
+		},
+	}
+	vmPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVM := e.ObjectOld.(*dvpcorev1alpha2.VirtualMachine)
+			newVM := e.ObjectNew.(*dvpcorev1alpha2.VirtualMachine)
+
+			return oldVM.Status.Phase != newVM.Status.Phase
+		},
+	}
+	pvcPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPVC := e.ObjectOld.(*corev1.PersistentVolumeClaim)
+			newPVC := e.ObjectNew.(*corev1.PersistentVolumeClaim)
+
+			return oldPVC.Status.Phase != newPVC.Status.Phase
+		},
+	}
+	dvPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDV := e.ObjectOld.(*cdiv1beta1.DataVolume)
+			newDV := e.ObjectNew.(*cdiv1beta1.DataVolume)
+
+			return oldDV.Status.Phase != newDV.Status.Phase || oldDV.Status.Progress != newDV.Status.Progress
+		},
+	}
+	vdPredicate = predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVD := e.ObjectOld.(*dvpcorev1alpha2.VirtualDisk)
+			newVD := e.ObjectNew.(*dvpcorev1alpha2.VirtualDisk)
+
+			return oldVD.Status.Phase != newVD.Status.Phase || oldVD.Status.Progress != newVD.Status.Progress
+		},
+	}
+	// dedicatedPredicate filters the children in the dedicated namespaces, they have no owner references.
+	dedicatedPredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[labelSandboxNamespace] != ""
+	})
+)
+
+// enqueueSandboxByLabels maps the child in the dedicated namespace to its sandbox.
+func enqueueSandboxByLabels() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		labels := obj.GetLabels()
+		if labels[labelSandboxName] == "" || labels[labelSandboxNamespace] == "" {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: labels[labelSandboxNamespace],
+					Name:      labels[labelSandboxName],
+				},
+			},
+		}
+	})
+}
+
 func isTTLExpired(sandbox *v1alpha1.Sandbox) bool {
 	return time.Now().After(sandbox.GetCreationTimestamp().Add(sandbox.Spec.TTL.Duration))
 }
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -17,7 +18,8 @@ import (
 
 func NewValidator(log *slog.Logger) admission.CustomValidator {
 	return validator.NewValidator[*v1alpha1.Sandbox](log.With("webhook", "validation")).
-		WithCreateValidators(volumesValidator{}, typeValidator{})
+		WithCreateValidators(volumesValidator{}, typeValidator{}, accessValidator{}).
+		WithUpdateValidators(ownerValidator{})
 }
 
 type volumesValidator struct {
@@ -37,11 +39,40 @@ type typeValidator struct {
 
 func (v typeValidator) ValidateCreate(ctx context.Context, sandbox *v1alpha1.Sandbox) (admission.Warnings, error) {
 	if sandbox.Spec.TemplateSpec != nil {
+		// Unstructured sandboxes can create resources of any kind, so they are allowed only from cluster-wide templates.
+		if sandbox.Spec.TemplateSpec.UnstructuredSpec != nil {
+			return admission.Warnings{}, fmt.Errorf("unstructuredSpec is allowed only in SandboxTemplate")
+		}
+		// The dedicated namespace is created with the quota and the role binding, so it is configured only by the cluster administrator.
+		if sandbox.Spec.TemplateSpec.Namespace == v1alpha1.NamespaceModeDedicated {
+			return admission.Warnings{}, fmt.Errorf("dedicated namespace is allowed only in SandboxTemplate")
+		}
 		return v.validator.Validate(ctx, sandbox.Spec.TemplateSpec)
 	}
 	return admission.Warnings{}, nil
 }
 
+type accessValidator struct {
+	validator service.AccessValidator
+}
+
+func (v accessValidator) ValidateCreate(ctx context.Context, sandbox *v1alpha1.Sandbox) (admission.Warnings, error) {
+	return v.validator.Validate(ctx, sandbox.Spec.Access)
+}
+
+// ownerValidator keeps the owner set by the defaulter, the owner is bound to the dedicated namespace of the sandbox,
+// so the user, who can update the sandbox, must not grant the access to the namespace to another subject.
+type ownerValidator struct{}
+
+func (v ownerValidator) ValidateUpdate(_ context.Context, oldSandbox, newSandbox *v1alpha1.Sandbox) (admission.Warnings, error) {
+	oldOwner, oldOk := oldSandbox.GetAnnotations()[v1alpha1.AnnotationSandboxOwner]
+	newOwner, newOk := newSandbox.GetAnnotations()[v1alpha1.AnnotationSandboxOwner]
+	if oldOk != newOk || oldOwner != newOwner {
+		return admission.Warnings{}, fmt.Errorf("annotation %s is immutable", v1alpha1.AnnotationSandboxOwner)
+	}
+	return admission.Warnings{}, nil
+}
+
 func NewDefaulter(log *slog.Logger) admission.CustomDefaulter {
 	return Defaulter{
 		log: log.With("webhook", "defaulter"),
@@ -52,7 +83,7 @@ type Defaulter struct {
 	log *slog.Logger
 }
 
-func (d Defaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d Defaulter) Default(ctx context.Context, obj runtime.Object) error {
 	sandbox, ok := obj.(*v1alpha1.Sandbox)
 	if !ok {
 		d.log.Error(fmt.Sprintf("Expected a Sandbox but got a %T", obj))
@@ -63,5 +94,12 @@ func (d Defaulter) Default(_ context.Context, obj runtime.Object) error {
 			Duration: time.Hour * 1,
 		}
 	}
+	// The owner is bound to the dedicated namespace of the sandbox.
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Create {
+		if sandbox.Annotations == nil {
+			sandbox.Annotations = make(map[string]string)
+		}
+		sandbox.Annotations[v1alpha1.AnnotationSandboxOwner] = req.UserInfo.Username
+	}
 	return nil
 }
@@ -2,9 +2,13 @@ package sandbox
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
@@ -25,6 +29,41 @@ func NewSandboxer(sandboxType v1alpha1.SandboxType, client client.Client, log *s
 		return NewDVPSandboxer(client, log)
 	case v1alpha1.SandboxTypeKubevirtVMI:
 		return NewKubevirtSandboxer(client, log)
+	case v1alpha1.SandboxTypeUnstructured:
+		return NewUnstructuredSandboxer(client, log)
+	case v1alpha1.SandboxTypeComposite:
+		return NewCompositeSandboxer(client, log)
+	}
+	if backend, ok := sandboxType.PluginBackend(); ok {
+		return NewPluginSandboxer(backend, client, log)
 	}
 	return nil
 }
+
+// getSyncPeriod returns the period of the status polling for the types, whose resources are not watched.
+func getSyncPeriod(sandboxType v1alpha1.SandboxType) time.Duration {
+	if sandboxType == v1alpha1.SandboxTypeUnstructured {
+		return unstructuredSyncPeriod
+	}
+	if _, ok := sandboxType.PluginBackend(); ok {
+		return pluginSyncPeriod
+	}
+	return 0
+}
+
+// getTemplateSpec returns the inline template spec or the spec of the sandbox template, nil if the template is not found.
+func getTemplateSpec(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox) (*v1alpha1.SandboxTemplateSpec, error) {
+	if sandbox.Spec.TemplateSpec != nil {
+		return sandbox.Spec.TemplateSpec, nil
+	}
+
+	sandboxTemplate := &v1alpha1.SandboxTemplate{}
+	err := c.Get(ctx, types.NamespacedName{Name: sandbox.Spec.Template}, sandboxTemplate)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get sandbox template %w", err)
+	}
+	return &sandboxTemplate.Spec, nil
+}
@@ -0,0 +1,252 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
+)
+
+// unstructuredSyncPeriod is the period of the status polling, the resources of arbitrary kinds are not watched.
+const unstructuredSyncPeriod = 10 * time.Second
+
+func NewUnstructuredSandboxer(client client.Client, log *slog.Logger) *UnstructuredSandboxer {
+	return &UnstructuredSandboxer{
+		client: client,
+		log:    log,
+	}
+}
+
+type UnstructuredSandboxer struct {
+	client client.Client
+	log    *slog.Logger
+}
+
+func (p UnstructuredSandboxer) Create(ctx context.Context, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) error {
+	if !featuregate.Enabled(featuregate.Unstructured) {
+		return fmt.Errorf("featuregate %s is not enabled", featuregate.Unstructured)
+	}
+	if templateSpec.UnstructuredSpec == nil {
+		return nil
+	}
+
+	objs, err := makeUnstructuredObjects(sandbox, templateSpec.UnstructuredSpec)
+	if err != nil {
+		return err
+	}
+	existingObjs, err := p.getObjects(ctx, objs)
+	if err != nil {
+		return err
+	}
+
+	failed, _, err := evaluateUnstructuredStatus(templateSpec.UnstructuredSpec.FailedExpression, existingObjs)
+	if err != nil {
+		return err
+	}
+	if failed {
+		return p.deleteObjects(ctx, existingObjs)
+	}
+
+	for i, obj := range objs {
+		if existingObjs[i] != nil {
+			continue
+		}
+		namespaced, err := p.client.IsObjectNamespaced(obj)
+		if err != nil {
+			return fmt.Errorf("failed to get scope of %s: %w", obj.GroupVersionKind().String(), err)
+		}
+		if !namespaced {
+			return fmt.Errorf("cluster-scoped %s is not supported", obj.GroupVersionKind().String())
+		}
+		if err = p.client.Create(ctx, obj); err != nil {
+			return fmt.Errorf("failed to create %s %q: %w", obj.GetKind(), client.ObjectKeyFromObject(obj).String(), err)
+		}
+	}
+
+	return nil
+}
+
+func (p UnstructuredSandboxer) Delete(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	if !featuregate.Enabled(featuregate.Unstructured) {
+		return fmt.Errorf("featuregate %s is not enabled", featuregate.Unstructured)
+	}
+
+	spec, err := p.getUnstructuredSpec(ctx, sandbox)
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		p.log.Warn("Cannot get unstructured spec, that's why child resources can be deleted in background")
+		return nil
+	}
+
+	objs, err := makeUnstructuredObjects(sandbox, spec)
+	if err != nil {
+		return err
+	}
+	existingObjs, err := p.getObjects(ctx, objs)
+	if err != nil {
+		return err
+	}
+
+	return p.deleteObjects(ctx, existingObjs)
+}
+
+func (p UnstructuredSandboxer) Status(ctx context.Context, sandbox *v1alpha1.Sandbox) (metav1.ConditionStatus, sandboxcondition.Reason, string, error) {
+	if !featuregate.Enabled(featuregate.Unstructured) {
+		return "", "", "", fmt.Errorf("featuregate %s is not enabled", featuregate.Unstructured)
+	}
+
+	var (
+		status  = metav1.ConditionFalse
+		reason  = sandboxcondition.ReasonPending
+		message string
+	)
+
+	spec, err := p.getUnstructuredSpec(ctx, sandbox)
+	if err != nil || spec == nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+	objs, err := makeUnstructuredObjects(sandbox, spec)
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+	existingObjs, err := p.getObjects(ctx, objs)
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+
+	failed, message, err := evaluateUnstructuredStatus(spec.FailedExpression, existingObjs)
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+	if failed {
+		return status, sandboxcondition.ReasonFailed, message, nil
+	}
+
+	ready, _, err := evaluateUnstructuredStatus(spec.ReadyExpression, existingObjs)
+	if err != nil {
+		return metav1.ConditionUnknown, "", "", err
+	}
+	if ready {
+		status = metav1.ConditionTrue
+		reason = sandboxcondition.ReasonReady
+	}
+
+	return status, reason, message, nil
+}
+
+func (p UnstructuredSandboxer) getUnstructuredSpec(ctx context.Context, sandbox *v1alpha1.Sandbox) (*v1alpha1.UnstructuredSpec, error) {
+	templateSpec, err := getTemplateSpec(ctx, p.client, sandbox)
+	if err != nil || templateSpec == nil {
+		return nil, err
+	}
+	return templateSpec.UnstructuredSpec, nil
+}
+
+// getObjects returns the existing objects in the order of objs, the missing objects are nil.
+func (p UnstructuredSandboxer) getObjects(ctx context.Context, objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	result := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := p.client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %w", obj.GetKind(), err)
+		}
+		result[i] = existing
+	}
+	return result, nil
+}
+
+func (p UnstructuredSandboxer) deleteObjects(ctx context.Context, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		if obj == nil {
+			continue
+		}
+		if err := p.client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %q", obj.GetKind(), client.ObjectKeyFromObject(obj).String())
+		}
+	}
+	return nil
+}
+
+// evaluateUnstructuredStatus evaluates the expression when all objects exist.
+func evaluateUnstructuredStatus(expression string, objs []*unstructured.Unstructured) (bool, string, error) {
+	if expression == "" {
+		return false, "", nil
+	}
+
+	objects := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		if obj == nil {
+			return false, "", nil
+		}
+		objects = append(objects, obj.Object)
+	}
+
+	program, err := common.CachedExpression(expression)
+	if err != nil {
+		return false, "", err
+	}
+	result, err := common.EvaluateExpression(program, objects)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to evaluate expression %q: %w", expression, err)
+	}
+	if !result {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("Expression %q is true", expression), nil
+}
+
+func makeUnstructuredObjects(sandbox *v1alpha1.Sandbox, spec *v1alpha1.UnstructuredSpec) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, manifest := range spec.Manifests {
+		obj, err := newUnstructuredObject(sandbox, manifest.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func newUnstructuredObject(sandbox *v1alpha1.Sandbox, manifest []byte) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for key, value := range newChildLabels(sandbox) {
+		labels[key] = value
+	}
+
+	obj.SetName(getFullUnstructuredName(obj.GetName(), sandbox))
+	obj.SetNamespace(common.GetChildNamespace(sandbox))
+	obj.SetLabels(labels)
+	obj.SetOwnerReferences(newChildOwnerReferences(sandbox))
+
+	return obj, nil
+}
+
+func getFullUnstructuredName(name string, sandbox *v1alpha1.Sandbox) string {
+	return fmt.Sprintf("%s-%s", common.GetFullName(sandbox), name)
+}
@@ -0,0 +1,181 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dvpcorev1alpha2 "github.com/deckhouse/virtualization/api/core/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
+)
+
+const (
+	// volumeStuckTimeout is the time after which the not provisioned volume is reported on the sandbox.
+	volumeStuckTimeout = 10 * time.Minute
+	volumeSyncPeriod   = time.Minute
+)
+
+type volumeStatus struct {
+	v1alpha1.SandboxVolumeStatus
+	stuck bool
+}
+
+// getVolumeStatuses returns the provisioning status of the volumes of the sandbox and its components.
+func getVolumeStatuses(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox, templateSpec *v1alpha1.SandboxTemplateSpec) ([]volumeStatus, error) {
+	var statuses []volumeStatus
+
+	if len(templateSpec.Components) == 0 {
+		return appendVolumeStatuses(ctx, c, statuses, sandbox, templateSpec.Volumes)
+	}
+	for _, component := range templateSpec.Components {
+		var err error
+		statuses, err = appendVolumeStatuses(ctx, c, statuses, common.WithComponent(sandbox, component.Name), component.Volumes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+func appendVolumeStatuses(ctx context.Context, c client.Client, statuses []volumeStatus, sandbox *v1alpha1.Sandbox, volumes []v1alpha1.SandboxVolumeSpec) ([]volumeStatus, error) {
+	for _, volume := range volumes {
+		var (
+			status volumeStatus
+			err    error
+		)
+		switch {
+		case volume.PVCSpec != nil:
+			status, err = getPVCStatus(ctx, c, sandbox, volume.Name)
+		case volume.DataVolumeSpec != nil && featuregate.Enabled(featuregate.Kubevirt):
+			status, err = getDVStatus(ctx, c, sandbox, volume.Name)
+		case volume.VirtualDiskSpec != nil && featuregate.Enabled(featuregate.DVP):
+			status, err = getVDStatus(ctx, c, sandbox, volume.Name)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		status.Name = volume.Name
+		status.Component = common.GetComponent(sandbox)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func getPVCStatus(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox, name string) (volumeStatus, error) {
+	status := newVolumeStatus("PersistentVolumeClaim", getFullPVCName(name, sandbox))
+	pvc := &corev1.PersistentVolumeClaim{}
+	found, err := getVolume(ctx, c, sandbox, status.ResourceName, pvc)
+	if err != nil || !found {
+		return status, err
+	}
+
+	status.Phase = string(pvc.Status.Phase)
+	status.Ready = pvc.Status.Phase == corev1.ClaimBound
+	if pvc.Status.Phase == corev1.ClaimLost {
+		status.Message = "The bound persistent volume is lost."
+	}
+	status.stuck = !status.Ready && isVolumeStuck(pvc)
+	return status, nil
+}
+
+func getDVStatus(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox, name string) (volumeStatus, error) {
+	status := newVolumeStatus("DataVolume", getFullDVName(name, sandbox))
+	dv := &cdiv1beta1.DataVolume{}
+	found, err := getVolume(ctx, c, sandbox, status.ResourceName, dv)
+	if err != nil || !found {
+		return status, err
+	}
+
+	status.Phase = string(dv.Status.Phase)
+	status.Progress = string(dv.Status.Progress)
+	status.Ready = dv.Status.Phase == cdiv1beta1.Succeeded
+	if !status.Ready {
+		for _, cond := range dv.Status.Conditions {
+			if cond.Type == cdiv1beta1.DataVolumeRunning && cond.Message != "" {
+				status.Message = cond.Message
+			}
+		}
+	}
+	status.stuck = !status.Ready && dv.Status.Phase != cdiv1beta1.WaitForFirstConsumer && isVolumeStuck(dv)
+	return status, nil
+}
+
+func getVDStatus(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox, name string) (volumeStatus, error) {
+	status := newVolumeStatus("VirtualDisk", getFullVDName(name, sandbox))
+	vd := &dvpcorev1alpha2.VirtualDisk{}
+	found, err := getVolume(ctx, c, sandbox, status.ResourceName, vd)
+	if err != nil || !found {
+		return status, err
+	}
+
+	status.Phase = string(vd.Status.Phase)
+	status.Progress = vd.Status.Progress
+	status.Ready = vd.Status.Phase == dvpcorev1alpha2.DiskReady
+	if !status.Ready {
+		if cond := meta.FindStatusCondition(vd.Status.Conditions, "Ready"); cond != nil {
+			status.Message = cond.Message
+		}
+	}
+	status.stuck = !status.Ready && vd.Status.Phase != dvpcorev1alpha2.DiskWaitForFirstConsumer && isVolumeStuck(vd)
+	return status, nil
+}
+
+func newVolumeStatus(kind, resourceName string) volumeStatus {
+	return volumeStatus{
+		SandboxVolumeStatus: v1alpha1.SandboxVolumeStatus{
+			Kind:         kind,
+			ResourceName: resourceName,
+			Message:      "The volume is not created yet.",
+		},
+	}
+}
+
+func getVolume(ctx context.Context, c client.Client, sandbox *v1alpha1.Sandbox, name string, obj client.Object) (bool, error) {
+	err := c.Get(ctx, client.ObjectKey{Namespace: common.GetChildNamespace(sandbox), Name: name}, obj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %T %q: %w", obj, name, err)
+	}
+	return true, nil
+}
+
+func isVolumeStuck(obj metav1.Object) bool {
+	return time.Since(obj.GetCreationTimestamp().Time) > volumeStuckTimeout
+}
+
+func areVolumesReady(statuses []volumeStatus) bool {
+	for _, status := range statuses {
+		if !status.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// getStuckVolumeMessage returns the message about the first stuck volume.
+func getStuckVolumeMessage(statuses []volumeStatus) string {
+	for _, status := range statuses {
+		if !status.stuck {
+			continue
+		}
+		message := fmt.Sprintf("%s %q is not provisioned for more than %s, phase %q", status.Kind, status.ResourceName, volumeStuckTimeout, status.Phase)
+		if status.Message != "" {
+			message += ": " + status.Message
+		}
+		return message
+	}
+	return ""
+}
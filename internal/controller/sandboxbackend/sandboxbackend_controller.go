@@ -0,0 +1,40 @@
+package sandboxbackend
+
+import (
+	"fmt"
+	"log/slog"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/reconciler"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+const (
+	controllerName = "sandboxbackend-controller"
+)
+
+func SetupController(mgr ctrl.Manager, log *slog.Logger) error {
+	log = log.With(logging.SlogController(controllerName))
+
+	c := mgr.GetClient()
+	r := reconciler.NewBaseReconciler(
+		v1alpha1.SandboxBackendKind,
+		c,
+		func() *v1alpha1.SandboxBackend {
+			return &v1alpha1.SandboxBackend{}
+		},
+		reconciler.NewStatusUpdater[*v1alpha1.SandboxBackend](c, func(obj *v1alpha1.SandboxBackend) interface{} {
+			return obj.Status
+		}),
+		reconciler.NewMetaUpdater[*v1alpha1.SandboxBackend](c),
+		NewReconciler(c))
+
+	if err := r.SetupWithManager(mgr, log); err != nil {
+		return fmt.Errorf("failed to setup %q: %w", controllerName, err)
+	}
+
+	log.Info("Registered sandboxbackend controller")
+	return nil
+}
@@ -0,0 +1,100 @@
+package sandboxbackend
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxbackendcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandboxbackend-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/controller/sandbox"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/condition"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/controller/reconciler"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+	pluginv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/pkg/plugin/v1alpha1"
+)
+
+// probePeriod is the period of the plugin server availability check.
+const probePeriod = time.Minute
+
+func NewReconciler(client client.Client) *Reconciler {
+	return &Reconciler{
+		client: client,
+	}
+}
+
+var _ reconciler.Reconciler[*v1alpha1.SandboxBackend] = &Reconciler{}
+
+type Reconciler struct {
+	client client.Client
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, sandboxBackend *v1alpha1.SandboxBackend) (reconcile.Result, error) {
+	if sandboxBackend == nil {
+		return reconcile.Result{}, nil
+	}
+	if !sandboxBackend.GetDeletionTimestamp().IsZero() {
+		if err := sandbox.ClosePluginBackendClient(sandboxBackend.Name); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to close connection to plugin: %w", err)
+		}
+		controllerutil.RemoveFinalizer(sandboxBackend, v1alpha1.FinalizerSandboxBackendConnection)
+		return reconcile.Result{}, nil
+	}
+	controllerutil.AddFinalizer(sandboxBackend, v1alpha1.FinalizerSandboxBackendConnection)
+
+	cb := condition.NewConditionBuilder(sandboxbackendcondition.TypeReady)
+	defer func() {
+		condition.SetCondition(cb, &sandboxBackend.Status.Conditions)
+	}()
+	cb.Generation(sandboxBackend.Generation).
+		Status(metav1.ConditionTrue).
+		Reason(sandboxbackendcondition.ReasonReady)
+
+	version, err := probe(ctx, r.client, sandboxBackend)
+	if err != nil {
+		cb.Status(metav1.ConditionFalse).
+			Reason(sandboxbackendcondition.ReasonUnavailable).
+			Message(err.Error())
+	}
+	sandboxBackend.Status.ProtocolVersion = version
+
+	return reconcile.Result{RequeueAfter: probePeriod}, nil
+}
+
+func probe(ctx context.Context, c client.Client, sandboxBackend *v1alpha1.SandboxBackend) (string, error) {
+	backendClient, timeout, err := sandbox.NewPluginBackendClient(ctx, c, sandboxBackend)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := backendClient.Info(ctx, &pluginv1alpha1.InfoRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get plugin info: %w", err)
+	}
+	if info.ProtocolVersion != pluginv1alpha1.ProtocolVersion {
+		return info.ProtocolVersion, fmt.Errorf("unsupported protocol version %q, expected %q", info.ProtocolVersion, pluginv1alpha1.ProtocolVersion)
+	}
+	return info.ProtocolVersion, nil
+}
+
+func (r *Reconciler) Setup(reconciler reconcile.Reconciler, mgr ctrl.Manager, log *slog.Logger) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&v1alpha1.SandboxBackend{}).
+		WithOptions(controller.Options{
+			RecoverPanic:   ptr.To(true),
+			LogConstructor: logging.NewConstructor(log),
+		}).
+		Complete(reconciler)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+type AccessValidator struct{}
+
+func (v *AccessValidator) Validate(_ context.Context, access *v1alpha1.SandboxAccess) (admission.Warnings, error) {
+	if access == nil {
+		return admission.Warnings{}, nil
+	}
+
+	for i, key := range access.SSHKeys {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key)); err != nil {
+			return admission.Warnings{}, fmt.Errorf("ssh key %d is invalid: %w", i, err)
+		}
+	}
+
+	return admission.Warnings{}, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
 )
 
@@ -28,7 +30,7 @@ func (v *VolumesValidator) Validate(_ context.Context, templateSpec *v1alpha1.Sa
 
 type TypeValidator struct{}
 
-func (v *TypeValidator) Validate(_ context.Context, templateSpec *v1alpha1.SandboxTemplateSpec) (admission.Warnings, error) {
+func (v *TypeValidator) Validate(ctx context.Context, templateSpec *v1alpha1.SandboxTemplateSpec) (admission.Warnings, error) {
 	switch {
 	case templateSpec.KubevirtVMISpec != nil:
 		if !featuregate.Enabled(featuregate.Kubevirt) {
@@ -38,6 +40,37 @@ func (v *TypeValidator) Validate(_ context.Context, templateSpec *v1alpha1.Sandb
 		if !featuregate.Enabled(featuregate.DVP) {
 			return admission.Warnings{}, fmt.Errorf("featuregate %s is not enabled", featuregate.DVP)
 		}
+	case templateSpec.UnstructuredSpec != nil:
+		if !featuregate.Enabled(featuregate.Unstructured) {
+			return admission.Warnings{}, fmt.Errorf("featuregate %s is not enabled", featuregate.Unstructured)
+		}
+		if len(templateSpec.Volumes) > 0 {
+			return admission.Warnings{}, fmt.Errorf("volumes are not supported for unstructuredSpec")
+		}
+		if err := validateUnstructuredSpec(templateSpec.UnstructuredSpec); err != nil {
+			return admission.Warnings{}, err
+		}
+	case len(templateSpec.Components) > 0:
+		if len(templateSpec.Volumes) > 0 {
+			return admission.Warnings{}, fmt.Errorf("volumes are not supported for components, use volumes of the components")
+		}
+		if err := v.validateComponents(ctx, templateSpec.Components); err != nil {
+			return admission.Warnings{}, err
+		}
+	case templateSpec.PluginSpec != nil:
+		if len(templateSpec.Volumes) > 0 {
+			return admission.Warnings{}, fmt.Errorf("volumes are not supported for pluginSpec")
+		}
+	}
+
+	if templateSpec.Completion != nil {
+		if err := validateCompletion(templateSpec); err != nil {
+			return admission.Warnings{}, err
+		}
+	}
+
+	if err := validateNamespace(templateSpec); err != nil {
+		return admission.Warnings{}, err
 	}
 
 	for _, volume := range templateSpec.Volumes {
@@ -55,3 +88,75 @@ func (v *TypeValidator) Validate(_ context.Context, templateSpec *v1alpha1.Sandb
 
 	return admission.Warnings{}, nil
 }
+
+func (v *TypeValidator) validateComponents(ctx context.Context, components []v1alpha1.SandboxComponent) error {
+	if _, err := common.SortComponents(components); err != nil {
+		return err
+	}
+	volumesValidator := &VolumesValidator{}
+	for _, component := range components {
+		componentSpec := common.GetComponentTemplateSpec(component)
+		if _, err := volumesValidator.Validate(ctx, componentSpec); err != nil {
+			return fmt.Errorf("component %q is invalid: %w", component.Name, err)
+		}
+		if _, err := v.Validate(ctx, componentSpec); err != nil {
+			return fmt.Errorf("component %q is invalid: %w", component.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateCompletion(templateSpec *v1alpha1.SandboxTemplateSpec) error {
+	switch common.DetectSandboxType(templateSpec) {
+	case v1alpha1.SandboxTypePod:
+	case v1alpha1.SandboxTypeKubevirtVMI:
+		if templateSpec.Completion.Artifacts != nil {
+			return fmt.Errorf("artifacts are supported only for podSpec")
+		}
+	default:
+		return fmt.Errorf("completion is supported only for podSpec and kubevirtVMISpec")
+	}
+	return nil
+}
+
+func validateNamespace(templateSpec *v1alpha1.SandboxTemplateSpec) error {
+	if templateSpec.Namespace != v1alpha1.NamespaceModeDedicated {
+		if templateSpec.DedicatedNamespace != nil {
+			return fmt.Errorf("dedicatedNamespace is allowed only with the Dedicated namespace mode")
+		}
+		return nil
+	}
+	// The artifacts claim outlives the sandbox, but the dedicated namespace is deleted with it.
+	if templateSpec.Completion != nil && templateSpec.Completion.Artifacts != nil {
+		return fmt.Errorf("artifacts are not supported with the Dedicated namespace mode")
+	}
+	return nil
+}
+
+func validateUnstructuredSpec(spec *v1alpha1.UnstructuredSpec) error {
+	names := make(map[string]struct{})
+	for i, manifest := range spec.Manifests {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			return fmt.Errorf("manifest %d is invalid: %w", i, err)
+		}
+		if obj.GetName() == "" {
+			return fmt.Errorf("manifest %d has no name", i)
+		}
+		key := obj.GroupVersionKind().GroupKind().String() + "/" + obj.GetName()
+		if _, exist := names[key]; exist {
+			return fmt.Errorf("manifest %s already exists", key)
+		}
+		names[key] = struct{}{}
+	}
+
+	if _, err := common.CompileExpression(spec.ReadyExpression); err != nil {
+		return err
+	}
+	if spec.FailedExpression != "" {
+		if _, err := common.CompileExpression(spec.FailedExpression); err != nil {
+			return err
+		}
+	}
+	return nil
+}
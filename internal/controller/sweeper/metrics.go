@@ -0,0 +1,35 @@
+package sweeper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "sandbox_orphan_sweeper"
+
+var (
+	orphansFound = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "orphans",
+		Help:      "Number of the orphaned child resources found by the last sweep.",
+	}, []string{"kind"})
+	orphansDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "deleted_total",
+		Help:      "Total number of the deleted orphaned child resources.",
+	}, []string{"kind"})
+	sweepErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "errors_total",
+		Help:      "Total number of the failed sweeps.",
+	})
+	lastSweepTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "last_sweep_timestamp_seconds",
+		Help:      "Unix time of the last completed sweep.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphansFound, orphansDeleted, sweepErrors, lastSweepTimestamp)
+}
@@ -0,0 +1,232 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	dvpcorev1alpha2 "github.com/deckhouse/virtualization/api/core/v1alpha2"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	virtv1 "kubevirt.io/api/core/v1"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/featuregate"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/logging"
+)
+
+const (
+	sweeperName = "orphan-sweeper"
+
+	// labelPrefix is the prefix of the labels, which the controller sets on the child resources.
+	labelPrefix      = "sandbox.io/"
+	labelArtifactsOf = labelPrefix + "artifacts-of"
+	// artifactsPrefix is the name prefix of the artifacts claims, they are retained after the sandbox deletion.
+	artifactsPrefix = common.NamePrefix + "artifacts-"
+
+	// gracePeriod protects the fresh resources, their sandbox may be not in the cache yet.
+	gracePeriod = 5 * time.Minute
+)
+
+type Options struct {
+	// Interval is the period of the sweeps, zero disables the sweeper.
+	Interval time.Duration
+	// DryRun reports the orphaned resources without the deletion.
+	DryRun bool
+}
+
+// SetupSweeper registers the sweeper of the child resources, which outlived their sandboxes.
+func SetupSweeper(mgr ctrl.Manager, opts Options, log *slog.Logger) error {
+	if opts.Interval == 0 {
+		return nil
+	}
+	log = log.With(logging.SlogController(sweeperName))
+	if err := mgr.Add(&Sweeper{
+		client: mgr.GetClient(),
+		opts:   opts,
+		log:    log,
+	}); err != nil {
+		return fmt.Errorf("failed to setup %q: %w", sweeperName, err)
+	}
+
+	log.Info("Registered orphan sweeper", slog.Duration("interval", opts.Interval), slog.Bool("dryRun", opts.DryRun))
+	return nil
+}
+
+type Sweeper struct {
+	client client.Client
+	opts   Options
+	log    *slog.Logger
+}
+
+func (s *Sweeper) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *Sweeper) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Sweep(ctx); err != nil {
+			sweepErrors.Inc()
+			s.log.Error("Failed to sweep orphaned resources", logging.SlogErr(err))
+		}
+	}, s.opts.Interval)
+	return nil
+}
+
+type sweptKind struct {
+	kind    string
+	newList func() client.ObjectList
+	enabled func() bool
+}
+
+var sweptKinds = []sweptKind{
+	{
+		kind:    "Pod",
+		newList: func() client.ObjectList { return &corev1.PodList{} },
+	},
+	{
+		kind:    "PersistentVolumeClaim",
+		newList: func() client.ObjectList { return &corev1.PersistentVolumeClaimList{} },
+	},
+	{
+		kind:    "VirtualMachineInstance",
+		newList: func() client.ObjectList { return &virtv1.VirtualMachineInstanceList{} },
+		enabled: func() bool { return featuregate.Enabled(featuregate.Kubevirt) },
+	},
+	{
+		kind:    "DataVolume",
+		newList: func() client.ObjectList { return &cdiv1beta1.DataVolumeList{} },
+		enabled: func() bool { return featuregate.Enabled(featuregate.Kubevirt) },
+	},
+	{
+		kind:    "VirtualMachine",
+		newList: func() client.ObjectList { return &dvpcorev1alpha2.VirtualMachineList{} },
+		enabled: func() bool { return featuregate.Enabled(featuregate.DVP) },
+	},
+	{
+		kind:    "VirtualDisk",
+		newList: func() client.ObjectList { return &dvpcorev1alpha2.VirtualDiskList{} },
+		enabled: func() bool { return featuregate.Enabled(featuregate.DVP) },
+	},
+}
+
+// Sweep deletes the child resources whose sandbox does not exist, in the dry-run mode they are only reported.
+// The failure of the kind does not stop the sweep of the other kinds, the errors are returned together.
+func (s *Sweeper) Sweep(ctx context.Context) error {
+	sandboxes := &v1alpha1.SandboxList{}
+	if err := s.client.List(ctx, sandboxes); err != nil {
+		return fmt.Errorf("failed to list sandboxes: %w", err)
+	}
+	uids := make(map[string]struct{}, len(sandboxes.Items))
+	for _, sandbox := range sandboxes.Items {
+		uids[string(sandbox.GetUID())] = struct{}{}
+	}
+
+	var errs []error
+	for _, k := range sweptKinds {
+		if k.enabled != nil && !k.enabled() {
+			continue
+		}
+		orphans, err := s.findOrphans(ctx, k, uids)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		orphansFound.WithLabelValues(k.kind).Set(float64(len(orphans)))
+
+		for _, obj := range orphans {
+			log := s.log.With(slog.String("kind", k.kind), slog.String("name", obj.GetName()), slog.String("namespace", obj.GetNamespace()))
+			if s.opts.DryRun {
+				log.Info("Found orphaned resource, skipping deletion in dry-run mode")
+				continue
+			}
+			if err = s.client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete %s %q: %w", k.kind, client.ObjectKeyFromObject(obj).String(), err))
+				continue
+			}
+			orphansDeleted.WithLabelValues(k.kind).Inc()
+			log.Info("Deleted orphaned resource")
+		}
+	}
+
+	lastSweepTimestamp.SetToCurrentTime()
+	return errors.Join(errs...)
+}
+
+func (s *Sweeper) findOrphans(ctx context.Context, k sweptKind, uids map[string]struct{}) ([]client.Object, error) {
+	list := k.newList()
+	if err := s.client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", k.kind, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", k.kind, err)
+	}
+
+	var orphans []client.Object
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || !obj.GetDeletionTimestamp().IsZero() || time.Since(obj.GetCreationTimestamp().Time) < gracePeriod {
+			continue
+		}
+		uid, ok := getSandboxUID(obj)
+		if !ok {
+			continue
+		}
+		if _, exists := uids[uid]; !exists {
+			orphans = append(orphans, obj)
+		}
+	}
+	return orphans, nil
+}
+
+// getSandboxUID returns the uid of the sandbox from the label or from the name `sandbox-<uid>[-suffix]`.
+// The name is trusted only for the resource, which is marked as the child of the sandbox otherwise,
+// so the foreign resource of the similar name is never deleted.
+func getSandboxUID(obj client.Object) (string, bool) {
+	if _, ok := obj.GetLabels()[labelArtifactsOf]; ok || strings.HasPrefix(obj.GetName(), artifactsPrefix) {
+		return "", false
+	}
+	if uid := obj.GetLabels()[v1alpha1.LabelSandboxUID]; uid != "" {
+		return uid, true
+	}
+
+	if !isSandboxChild(obj) {
+		return "", false
+	}
+	name, ok := strings.CutPrefix(obj.GetName(), common.NamePrefix)
+	if !ok || len(name) < 36 {
+		return "", false
+	}
+	uid := name[:36]
+	if _, err := uuid.Parse(uid); err != nil {
+		return "", false
+	}
+	return uid, true
+}
+
+// isSandboxChild reports whether the resource is owned by the sandbox or has the label of the sandbox.
+func isSandboxChild(obj client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == v1alpha1.SandboxKind && strings.HasPrefix(ref.APIVersion, v1alpha1.SchemeGroupVersion.Group+"/") {
+			return true
+		}
+	}
+	for key := range obj.GetLabels() {
+		if strings.HasPrefix(key, labelPrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package sweeper
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+const testUID = "0b5c3c2e-8f0a-4c47-9d1e-3f6f2a1b9c7d"
+
+func TestGetSandboxUID(t *testing.T) {
+	sandboxOwner := metav1.OwnerReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       v1alpha1.SandboxKind,
+		Name:       "my-sandbox",
+		UID:        testUID,
+	}
+	foreignOwner := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "sandbox-" + testUID,
+	}
+
+	tests := []struct {
+		name    string
+		meta    metav1.ObjectMeta
+		wantUID string
+		wantOK  bool
+	}{
+		{
+			name:    "uid label",
+			meta:    metav1.ObjectMeta{Name: "anything", Labels: map[string]string{v1alpha1.LabelSandboxUID: testUID}},
+			wantUID: testUID,
+			wantOK:  true,
+		},
+		{
+			name:    "name with the sandbox owner",
+			meta:    metav1.ObjectMeta{Name: "sandbox-" + testUID, OwnerReferences: []metav1.OwnerReference{sandboxOwner}},
+			wantUID: testUID,
+			wantOK:  true,
+		},
+		{
+			name:    "name with the suffix and the sandbox label",
+			meta:    metav1.ObjectMeta{Name: "sandbox-" + testUID + "-disk", Labels: map[string]string{"sandbox.io/component": "db"}},
+			wantUID: testUID,
+			wantOK:  true,
+		},
+		{
+			name: "name without the sandbox marks",
+			meta: metav1.ObjectMeta{Name: "sandbox-" + testUID},
+		},
+		{
+			name: "name with the foreign owner",
+			meta: metav1.ObjectMeta{Name: "sandbox-" + testUID, OwnerReferences: []metav1.OwnerReference{foreignOwner}},
+		},
+		{
+			name: "name with the foreign label",
+			meta: metav1.ObjectMeta{Name: "sandbox-" + testUID, Labels: map[string]string{"app": "sandbox"}},
+		},
+		{
+			name: "name with the invalid uid",
+			meta: metav1.ObjectMeta{Name: "sandbox-not-a-uid-but-long-enough-to-be-parsed", OwnerReferences: []metav1.OwnerReference{sandboxOwner}},
+		},
+		{
+			name: "short name",
+			meta: metav1.ObjectMeta{Name: "sandbox-abc", OwnerReferences: []metav1.OwnerReference{sandboxOwner}},
+		},
+		{
+			name: "artifacts label",
+			meta: metav1.ObjectMeta{Name: "results", Labels: map[string]string{labelArtifactsOf: "my-sandbox", v1alpha1.LabelSandboxUID: testUID}},
+		},
+		{
+			name: "artifacts name",
+			meta: metav1.ObjectMeta{Name: artifactsPrefix + testUID, OwnerReferences: []metav1.OwnerReference{sandboxOwner}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uid, ok := getSandboxUID(&corev1.PersistentVolumeClaim{ObjectMeta: tt.meta})
+			if uid != tt.wantUID || ok != tt.wantOK {
+				t.Errorf("getSandboxUID() = (%q, %v), want (%q, %v)", uid, ok, tt.wantUID, tt.wantOK)
+			}
+		})
+	}
+}
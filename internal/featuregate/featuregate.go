@@ -13,8 +13,9 @@ func (f FeatureGate) String() string {
 }
 
 const (
-	Kubevirt FeatureGate = "KUBEVIRT"
-	DVP      FeatureGate = "DVP"
+	Kubevirt     FeatureGate = "KUBEVIRT"
+	DVP          FeatureGate = "DVP"
+	Unstructured FeatureGate = "UNSTRUCTURED"
 )
 
 var defaultFeatureGates = FeatureGates{}
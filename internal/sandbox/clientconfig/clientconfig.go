@@ -33,3 +33,16 @@ func ClientAndNamespaceFromContext(ctx context.Context) (client kubeclient.Clien
 	}
 	return client, namespace, overridden, nil
 }
+
+// CurrentContextFromContext returns the name of the current context of the kubeconfig.
+func CurrentContextFromContext(ctx context.Context) (string, error) {
+	clientConfig, ok := ctx.Value(clientConfigKey).(clientcmd.ClientConfig)
+	if !ok {
+		return "", fmt.Errorf("unable to get client config from context")
+	}
+	raw, err := clientConfig.RawConfig()
+	if err != nil {
+		return "", err
+	}
+	return raw.CurrentContext, nil
+}
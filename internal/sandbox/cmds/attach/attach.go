@@ -13,37 +13,61 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
 	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/terminal"
 )
 
 const (
 	example = `  # Attach to the sandbox 'my-sandbox':
   {{ProgramName}} attach my-sandbox
-  {{ProgramName}} attach my-sandbox -n my-namespace`
+  {{ProgramName}} attach my-sandbox -n my-namespace
+  # Attach to the component 'app' of the multi-component sandbox 'my-sandbox':
+  {{ProgramName}} attach my-sandbox --component app
+  # Watch the session of the sandbox 'my-sandbox' read-only:
+  {{ProgramName}} attach my-sandbox --observe
+  # Take control of the session of the sandbox 'my-sandbox':
+  {{ProgramName}} attach my-sandbox --control`
 
 	long = `Attach to a sandbox.
 
-The sandbox must be in the running phase.`
+The sandbox must be in the running phase.
+The size of the terminal is passed to the pod sandboxes, the consoles of the virtual machines are serial and keep their size.
+The clients attached to the same pod sandbox share the session: all of them see the output,
+the input is accepted only from the driver. The first client drives the session, the others join read-only
+until the driver leaves. Use --control to take control of the session and --observe to watch it.`
 )
 
-type attach struct{}
+type attach struct {
+	component string
+	observe   bool
+	control   bool
+}
 
 func NewAttachSandboxCommand() *cobra.Command {
 	a := &attach{}
 
 	cmd := &cobra.Command{
-		Use:     "attach",
-		Short:   "Attach to a sandbox",
-		Example: example,
-		Long:    long,
-		Args:    cobra.ExactArgs(1),
-		RunE:    a.Run,
+		Use:               "attach",
+		Short:             "Attach to a sandbox",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SandboxName,
+		RunE:              a.Run,
 	}
 
+	cmd.Flags().StringVar(&a.component, "component", "", "Component of the multi-component sandbox to attach to")
+	cmd.Flags().BoolVar(&a.observe, "observe", false, "Join the live session read-only")
+	cmd.Flags().BoolVar(&a.control, "control", false, "Take control of the live session")
+	cmd.MarkFlagsMutuallyExclusive("observe", "control")
+
 	cmd.SetUsageTemplate(template.UsageTemplate())
 	return cmd
 }
@@ -55,6 +79,14 @@ func (a *attach) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var mode subv1alpha1.AttachMode
+	switch {
+	case a.observe:
+		mode = subv1alpha1.AttachModeObserve
+	case a.control:
+		mode = subv1alpha1.AttachModeControl
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	go func() {
 		<-interrupt
@@ -63,12 +95,14 @@ func (a *attach) Run(cmd *cobra.Command, args []string) error {
 	signal.Notify(interrupt, os.Interrupt)
 
 	for {
-		err := connect(name, namespace, client)
-		if err == nil {
+		err := Connect(name, namespace, a.component, mode, client)
+		// The session is over, the exit code of the shell is not an error of the attach.
+		var exitErr exec.CodeExitError
+		if err == nil || errors.As(err, &exitErr) {
 			continue
 		}
 		if errors.Is(err, ErrorInterrupt) || strings.Contains(err.Error(), "not found") {
-			return ignoreInterrupt(err)
+			return IgnoreInterrupt(err)
 		}
 
 		var e *websocket.CloseError
@@ -93,8 +127,9 @@ func (a *attach) Run(cmd *cobra.Command, args []string) error {
 	}
 }
 
-func connect(name string, namespace string, client kubeclient.Client,
-) error {
+// Connect attaches the terminal to the sandbox for one session.
+// The session ends with ErrorInterrupt, when the escape sequence is typed or the connection is closed.
+func Connect(name, namespace, component string, mode subv1alpha1.AttachMode, client kubeclient.Client) error {
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
 
@@ -103,8 +138,19 @@ func connect(name string, namespace string, client kubeclient.Client,
 	resChan := make(chan error)
 	runningChan := make(chan error)
 
+	var sizeQueue remotecommand.TerminalSizeQueue
+	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
+		queue := terminal.NewSizeQueue(fd)
+		defer queue.Stop()
+		sizeQueue = queue
+	}
+
 	go func() {
-		con, err := client.Sandboxes(namespace).Attach(name, &subv1alpha1.Attach{ConnectionTimeout: metav1.Duration{Duration: 1 * time.Minute}})
+		con, err := client.Sandboxes(namespace).Attach(name, &subv1alpha1.Attach{
+			ConnectionTimeout: metav1.Duration{Duration: 1 * time.Minute},
+			Component:         component,
+			Mode:              mode,
+		})
 		runningChan <- err
 
 		if err != nil {
@@ -112,8 +158,9 @@ func connect(name string, namespace string, client kubeclient.Client,
 		}
 
 		resChan <- con.Stream(kubeclient.StreamOptions{
-			In:  stdinReader,
-			Out: stdoutWriter,
+			In:        stdinReader,
+			Out:       stdoutWriter,
+			SizeQueue: sizeQueue,
 		})
 	}()
 
@@ -125,7 +172,8 @@ func connect(name string, namespace string, client kubeclient.Client,
 	return attachTerm(stdoutReader, stdinWriter, name, resChan)
 }
 
-func ignoreInterrupt(err error) error {
+// IgnoreInterrupt returns nil, if the session is interrupted by the user.
+func IgnoreInterrupt(err error) error {
 	if errors.Is(err, ErrorInterrupt) {
 		return nil
 	}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+// NewSandbox returns the sandbox without the template, the empty name is generated by the server with the prefix.
+func NewSandbox(name, generateName, namespace string, ttl time.Duration) *v1alpha1.Sandbox {
+	sandbox := &v1alpha1.Sandbox{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.SandboxKind,
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.SandboxSpec{
+			TTL: metav1.Duration{
+				Duration: ttl,
+			},
+		},
+	}
+	if name == "" {
+		sandbox.GenerateName = generateName
+	}
+	return sandbox
+}
@@ -0,0 +1,88 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # Load the bash completion in the current shell
+  source <({{ProgramName}} completion bash)
+  # Install the zsh completion
+  {{ProgramName}} completion zsh > "${fpath[1]}/_{{ProgramName}}"
+  # Install the fish completion
+  {{ProgramName}} completion fish > ~/.config/fish/completions/{{ProgramName}}.fish
+  # Load the powershell completion in the current session
+  {{ProgramName}} completion powershell | Out-String | Invoke-Expression
+  # Install the completion of the kubectl plugin, kubectl completes 'kubectl sandbox' with it
+  {{ProgramName}} completion kubectl > ~/.local/bin/kubectl_complete-sandbox && chmod +x ~/.local/bin/kubectl_complete-sandbox`
+
+	long = `Generate the shell completion script.
+
+The names of the sandboxes in the current namespace and the names of the templates are completed dynamically.
+When the binary is installed as the kubectl plugin, kubectl completes the plugin with the executable kubectl_complete-<plugin> in the PATH:
+install the script of 'completion kubectl' or link the binary under this name.`
+
+	shellBash       = "bash"
+	shellZsh        = "zsh"
+	shellFish       = "fish"
+	shellPowerShell = "powershell"
+	shellKubectl    = "kubectl"
+
+	kubectlScript = `#!/usr/bin/env sh
+# The completion of the kubectl plugin %[1]s, kubectl calls it to complete 'kubectl %[1]s'.
+exec kubectl %[1]s %[2]s "$@"
+`
+)
+
+// NewCompletionCommand returns the completion command, the plugin is the name of the kubectl plugin,
+// it is empty if the binary is not run by kubectl.
+func NewCompletionCommand(plugin string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "completion [bash|zsh|fish|powershell|kubectl]",
+		Short:                 "Generate the shell completion script",
+		Example:               example,
+		Long:                  long,
+		DisableFlagsInUseLine: true,
+		ValidArgs:             []string{shellBash, shellZsh, shellFish, shellPowerShell, shellKubectl},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd, args[0], plugin)
+		},
+	}
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func run(cmd *cobra.Command, shell, plugin string) error {
+	out := cmd.OutOrStdout()
+	root := cmd.Root()
+
+	if shell == shellKubectl {
+		if plugin == "" {
+			plugin = root.Name()
+		}
+		_, err := fmt.Fprintf(out, kubectlScript, plugin, cobra.ShellCompRequestCmd)
+		return err
+	}
+	// The script of the shell would complete kubectl itself instead of the plugin.
+	if plugin != "" {
+		return fmt.Errorf("kubectl completes its plugins, install the script of 'kubectl %s completion %s' instead of the %s completion", plugin, shellKubectl, shell)
+	}
+
+	switch shell {
+	case shellBash:
+		return root.GenBashCompletionV2(out, true)
+	case shellZsh:
+		return root.GenZshCompletion(out)
+	case shellFish:
+		return root.GenFishCompletion(out, true)
+	default:
+		return root.GenPowerShellCompletionWithDesc(out)
+	}
+}
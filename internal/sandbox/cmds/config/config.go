@@ -0,0 +1,194 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/config"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # Set the default template and TTL of the current kube context
+  {{ProgramName}} config set template my-template
+  {{ProgramName}} config set ttl 4h
+  # Set the default namespace of the kube context 'prod'
+  {{ProgramName}} config set namespace sandboxes --context prod
+  # Set the template of the profile 'vm' and use it
+  {{ProgramName}} config set template vm-ubuntu --profile vm
+  {{ProgramName}} create my-vm --profile vm
+  # Print the default template of the current kube context
+  {{ProgramName}} config get template
+  # Print the config file
+  {{ProgramName}} config view`
+
+	long = `Manage the config file of the defaults.
+
+The file is ~/.config/sandbox/config.yaml, $XDG_CONFIG_HOME and $SANDBOX_CONFIG change its path.
+The defaults are kept per kube context and in the named profiles, the profile selected with --profile
+overrides the defaults of the context. The flags, which are not passed, fall back to the defaults:
+namespace, template, ttl and sshKey are the flags --namespace, --template, --ttl and --ssh-key,
+attachCommand is the command of exec, when the command is not passed.`
+)
+
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Manage the defaults of the flags in the config file",
+		Example: example,
+		Long:    long,
+		Args:    cobra.NoArgs,
+		// The config is not applied to its own commands, so the missing profile can be created.
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.AddCommand(
+		newSetCommand(),
+		newGetCommand(),
+		newViewCommand(),
+	)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func newSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set the default of the current kube context or of the profile, the empty value unsets it",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return config.Keys, cobra.ShellCompDirectiveNoFileComp
+		},
+
+		RunE: set,
+	}
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func set(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	profile, _ := cmd.Flags().GetString(config.FlagProfile)
+	var (
+		defaults map[string]config.Defaults
+		target   string
+	)
+	if profile != "" {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]config.Defaults)
+		}
+		defaults, target = c.Profiles, fmt.Sprintf("profile %s", profile)
+	} else {
+		kubeContext, err := config.KubeContext(cmd)
+		if err != nil {
+			return err
+		}
+		if kubeContext == "" {
+			return fmt.Errorf("no kube context is selected, pass --context or --profile")
+		}
+		if c.Contexts == nil {
+			c.Contexts = make(map[string]config.Defaults)
+		}
+		defaults, target, profile = c.Contexts, fmt.Sprintf("context %s", kubeContext), kubeContext
+	}
+
+	d := defaults[profile]
+	if err = d.Set(key, value); err != nil {
+		return err
+	}
+	defaults[profile] = d
+	if err = c.Save(); err != nil {
+		return err
+	}
+
+	if value == "" {
+		cmd.Printf("Unset %s of %s.\n", key, target)
+	} else {
+		cmd.Printf("Set %s of %s to %s.\n", key, target, value)
+	}
+	return nil
+}
+
+func newGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get KEY",
+		Short: "Print the default of the current kube context overridden by the profile",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return config.Keys, cobra.ShellCompDirectiveNoFileComp
+		},
+
+		RunE: get,
+	}
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func get(cmd *cobra.Command, args []string) error {
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+	kubeContext, err := config.KubeContext(cmd)
+	if err != nil {
+		return err
+	}
+	profile, _ := cmd.Flags().GetString(config.FlagProfile)
+	d, err := c.Resolve(kubeContext, profile)
+	if err != nil {
+		return err
+	}
+
+	value, err := d.Get(args[0])
+	if err != nil {
+		return err
+	}
+	cmd.Println(value)
+	return nil
+}
+
+func newViewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Print the config file",
+		Args:  cobra.NoArgs,
+
+		RunE: view,
+	}
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func view(cmd *cobra.Command, _ []string) error {
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("# %s\n%s", path, data)
+	return nil
+}
@@ -2,39 +2,53 @@ package create
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/waiter"
 )
 
 const (
 	example = `  # Create sandbox
   {{ProgramName}} create my-sandbox
   # Create sandbox with dry-run
-  {{ProgramName}} create --dry-run my-sandbox`
+  {{ProgramName}} create --dry-run my-sandbox
+  # Create sandbox with ssh key authorized in the virtual machine
+  {{ProgramName}} create --template my-vm-template --ssh-key ~/.ssh/id_ed25519.pub my-sandbox
+  # Create sandbox and wait until it is ready
+  {{ProgramName}} create --template my-template --wait --timeout 5m my-sandbox`
 )
 
 type create struct {
 	template string
 	ttl      time.Duration
 	print    bool
+	sshKeys  []string
+	wait     bool
+	timeout  time.Duration
 }
 
 func NewCreateSandboxCommand() *cobra.Command {
 	c := &create{}
 
 	cmd := &cobra.Command{
-		Use:     "create [Name]",
-		Short:   "Create sandbox",
-		Example: example,
-		Args:    cobra.ExactArgs(1),
+		Use:               "create [Name]",
+		Short:             "Create sandbox",
+		Example:           example,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
 
 		RunE: c.Run,
 	}
@@ -42,15 +56,20 @@ func NewCreateSandboxCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&c.template, "template", "t", "", "Template name")
 	cmd.Flags().DurationVarP(&c.ttl, "ttl", "l", 1*time.Hour, "Sandbox TTL")
 	cmd.Flags().BoolVarP(&c.print, "print", "p", false, "Print the created sandbox")
+	cmd.Flags().StringSliceVar(&c.sshKeys, "ssh-key", nil, "Path to the public SSH key file to authorize in the virtual machine sandbox")
+	cmd.Flags().BoolVarP(&c.wait, "wait", "w", false, "Wait until the sandbox is ready, the progress is printed while waiting")
+	cmd.Flags().DurationVar(&c.timeout, "timeout", 10*time.Minute, "Time to wait for the sandbox with --wait, zero means no timeout")
 	common.SetDryRun(cmd.Flags())
 
+	_ = cmd.RegisterFlagCompletionFunc("template", completion.TemplateNames)
+
 	cmd.SetUsageTemplate(template.UsageTemplate())
 	return cmd
 }
 
 func (c *create) Run(cmd *cobra.Command, args []string) error {
 	if c.template == "" {
-		return fmt.Errorf("--template is required")
+		return fmt.Errorf("--template is required, pass it or set the template in the config file")
 	}
 
 	name := args[0]
@@ -59,7 +78,17 @@ func (c *create) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	sandbox := newSandbox(name, namespace, c.template, c.ttl)
+	sandbox := common.NewSandbox(name, "", namespace, c.ttl)
+	sandbox.Spec.Template = c.template
+	if len(c.sshKeys) > 0 {
+		keys, err := readSSHKeys(c.sshKeys)
+		if err != nil {
+			return err
+		}
+		sandbox.Spec.Access = &v1alpha1.SandboxAccess{
+			SSHKeys: keys,
+		}
+	}
 
 	opts := metav1.CreateOptions{
 		DryRun: common.GetDryRun(),
@@ -81,24 +110,40 @@ func (c *create) Run(cmd *cobra.Command, args []string) error {
 		cmd.Println(string(bytes))
 	}
 
+	if c.wait && !common.IsDryRun() {
+		if err = waiter.Wait(cmd.Context(), client, namespace, name, waiter.ConditionReady, c.timeout, cmd.ErrOrStderr()); err != nil {
+			return err
+		}
+		cmd.Printf("Sandbox %s is ready.\n", name)
+	}
+
 	return nil
 }
 
-func newSandbox(name, namespace, template string, ttl time.Duration) *v1alpha1.Sandbox {
-	return &v1alpha1.Sandbox{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       v1alpha1.SandboxKind,
-			APIVersion: v1alpha1.SchemeGroupVersion.Version,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: v1alpha1.SandboxSpec{
-			Template: template,
-			TTL: &metav1.Duration{
-				Duration: ttl,
-			},
-		},
+func readSSHKeys(paths []string) ([]string, error) {
+	var keys []string
+	for _, path := range paths {
+		if strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			path = filepath.Join(home, strings.TrimPrefix(path, "~/"))
+		}
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ssh key: %w", err)
+		}
+		for _, line := range strings.Split(string(bytes), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if _, _, _, _, err = ssh.ParseAuthorizedKey([]byte(line)); err != nil {
+				return nil, fmt.Errorf("invalid ssh key in %s: %w", path, err)
+			}
+			keys = append(keys, line)
+		}
 	}
+	return keys, nil
 }
@@ -1,55 +1,246 @@
 package delete
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/config"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/waiter"
 )
 
 const (
 	example = `  # Delete sandbox
   {{ProgramName}} delete my-sandbox
   # Delete sandbox with dry-run
-  {{ProgramName}} delete --dry-run my-sandbox`
+  {{ProgramName}} delete --dry-run my-sandbox
+  # Delete all sandboxes in the namespace without the confirmation
+  {{ProgramName}} delete --all --yes
+  # Delete the failed sandboxes of the team 'qa'
+  {{ProgramName}} delete -l team=qa --failed
+  # Delete the sandboxes of the template 'foo' older than a day
+  {{ProgramName}} delete --template foo --older-than 24h
+  # Delete sandbox and wait until its child resources are removed
+  {{ProgramName}} delete my-sandbox --wait
+  # Delete sandbox and remove its finalizer, if it is not deleted in 2 minutes
+  {{ProgramName}} delete my-sandbox --force --timeout 2m`
+
+	long = `Delete sandboxes by the names or by the filters.
+
+The filters --all, -l, --failed, --older-than and --template select the sandboxes in the namespace,
+all the passed filters must match. The selected sandboxes are printed and deleted after the confirmation,
+--yes skips it.
+With --wait the command waits until the controller removes the child resources and the sandboxes are gone.
+With --force the finalizer of the sandboxes, which are not gone before the timeout, is removed,
+then the child resources, which are not removed yet, remain in the cluster.`
 )
 
-type delete struct{}
+type delete struct {
+	all       bool
+	selector  string
+	failed    bool
+	olderThan time.Duration
+	template  string
+	yes       bool
+	wait      bool
+	force     bool
+	timeout   time.Duration
+}
 
 func NewDeleteSandboxCommand() *cobra.Command {
 	d := &delete{}
 
 	cmd := &cobra.Command{
-		Use:     "delete [Name]",
-		Short:   "Delete sandbox",
-		Example: example,
-		Args:    cobra.ExactArgs(1),
+		Use:               "delete [Name...]",
+		Short:             "Delete sandboxes",
+		Example:           example,
+		Long:              long,
+		ValidArgsFunction: completion.SandboxNames,
 
 		RunE: d.Run,
 	}
 
 	common.SetDryRun(cmd.Flags())
+	cmd.Flags().BoolVar(&d.all, "all", false, "Delete all sandboxes in the namespace")
+	cmd.Flags().StringVarP(&d.selector, "selector", "l", "", "Label selector of the sandboxes to delete, for example -l key1=value1,key2=value2")
+	cmd.Flags().BoolVar(&d.failed, "failed", false, "Delete only the failed sandboxes")
+	cmd.Flags().DurationVar(&d.olderThan, "older-than", 0, "Delete only the sandboxes created earlier than the duration ago")
+	cmd.Flags().StringVar(&d.template, "template", "", "Delete only the sandboxes of the template")
+	cmd.Flags().BoolVarP(&d.yes, "yes", "y", false, "Delete the selected sandboxes without the confirmation")
+	cmd.Flags().BoolVar(&d.wait, "wait", false, "Wait until the sandboxes and their child resources are deleted")
+	cmd.Flags().BoolVar(&d.force, "force", false, "Remove the finalizer of the sandboxes, which are not deleted before the timeout, implies --wait")
+	cmd.Flags().DurationVar(&d.timeout, "timeout", 5*time.Minute, "Time to wait for the deletion of all sandboxes, zero means no timeout")
+	_ = cmd.RegisterFlagCompletionFunc("template", completion.TemplateNames)
+	// The template is the filter, the default template of the config file would delete its sandboxes.
+	_ = config.NoDefault(cmd, "template")
 
 	cmd.SetUsageTemplate(template.UsageTemplate())
 	return cmd
 }
 
-func (d delete) Run(cmd *cobra.Command, args []string) error {
-	name := args[0]
+func (d *delete) Run(cmd *cobra.Command, args []string) error {
+	filtered := d.all || d.selector != "" || d.failed || d.olderThan > 0 || d.template != ""
+	switch {
+	case len(args) > 0 && filtered:
+		return fmt.Errorf("the names cannot be combined with --all, -l, --failed, --older-than and --template")
+	case len(args) == 0 && !filtered:
+		return fmt.Errorf("the name of the sandbox or one of --all, -l, --failed, --older-than and --template is required")
+	case d.force && d.timeout == 0:
+		return fmt.Errorf("--force requires the non-zero --timeout")
+	}
+
 	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
+	names := args
+	if filtered {
+		names, err = d.selectSandboxes(cmd, client, namespace)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			cmd.Printf("No sandboxes found in %s namespace.\n", namespace)
+			return nil
+		}
+		if !d.yes && !common.IsDryRun() {
+			ok, err := confirm(cmd, namespace, names)
+			if err != nil || !ok {
+				return err
+			}
+		}
+	}
+
 	opts := metav1.DeleteOptions{
 		DryRun: common.GetDryRun(),
 	}
 
+	suffix := ""
 	if common.IsDryRun() {
 		cmd.Println("Dry run mode, no resources will be deleted.")
+		suffix = " (dry run)"
+	}
+
+	var deleted []string
+	for _, name := range names {
+		err = client.Sandboxes(namespace).Delete(cmd.Context(), name, opts)
+		// The selected sandbox can be deleted by the controller after its TTL.
+		if apierrors.IsNotFound(err) && filtered {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Printf("sandbox/%s deleted%s\n", name, suffix)
+		deleted = append(deleted, name)
+	}
+
+	if (!d.wait && !d.force) || common.IsDryRun() {
+		return nil
+	}
+	return d.waitDeleted(cmd, client, namespace, deleted)
+}
+
+// selectSandboxes returns the names of the sandboxes in the namespace, which match all the filters.
+func (d *delete) selectSandboxes(cmd *cobra.Command, client kubeclient.Client, namespace string) ([]string, error) {
+	sandboxes, err := client.Sandboxes(namespace).List(cmd.Context(), metav1.ListOptions{LabelSelector: d.selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, sandbox := range sandboxes.Items {
+		if d.failed && !isFailed(&sandbox) {
+			continue
+		}
+		if d.olderThan > 0 && time.Since(sandbox.CreationTimestamp.Time) < d.olderThan {
+			continue
+		}
+		if d.template != "" && sandbox.Spec.Template != d.template {
+			continue
+		}
+		names = append(names, sandbox.Name)
 	}
+	return names, nil
+}
 
-	return client.Sandboxes(namespace).Delete(cmd.Context(), name, opts)
+func isFailed(sandbox *v1alpha1.Sandbox) bool {
+	if result := sandbox.Status.Result; result != nil && result.Phase == v1alpha1.SandboxResultPhaseFailed {
+		return true
+	}
+	for _, c := range sandbox.Status.Conditions {
+		if c.Type == sandboxcondition.TypeReady.String() {
+			return c.Reason == sandboxcondition.ReasonFailed.String()
+		}
+	}
+	return false
+}
+
+// confirm prints the selected sandboxes and asks to delete them, the answer is read from the terminal.
+func confirm(cmd *cobra.Command, namespace string, names []string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("unable to confirm the deletion of %d sandboxes, input is not a terminal, pass --yes", len(names))
+	}
+
+	for _, name := range names {
+		cmd.PrintErrf("sandbox/%s\n", name)
+	}
+	cmd.PrintErrf("Delete %d sandboxes in %s namespace? [y/N]: ", len(names), namespace)
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	cmd.PrintErrln("Deletion is cancelled.")
+	return false, nil
+}
+
+// waitDeleted waits until the sandboxes are gone, the controller removes their finalizer after the child resources.
+// With --force the finalizer of the sandboxes, which are not gone before the timeout, is removed.
+func (d *delete) waitDeleted(cmd *cobra.Command, client kubeclient.Client, namespace string, names []string) error {
+	// The timeout is shared by the sandboxes.
+	var deadline time.Time
+	if d.timeout > 0 {
+		deadline = time.Now().Add(d.timeout)
+	}
+
+	for _, name := range names {
+		timeout := time.Duration(0)
+		if !deadline.IsZero() {
+			timeout = max(time.Until(deadline), time.Nanosecond)
+		}
+		err := waiter.Wait(cmd.Context(), client, namespace, name, waiter.ConditionDeleted, timeout, nil)
+		if errors.Is(err, waiter.ErrTimeout) && d.force {
+			if err = removeFinalizer(cmd.Context(), client, namespace, name); err != nil {
+				return err
+			}
+			cmd.PrintErrf("Warning: the finalizer of sandbox/%s is removed, its child resources may remain\n", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Printf("sandbox/%s gone\n", name)
+	}
+	return nil
 }
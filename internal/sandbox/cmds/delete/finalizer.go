@@ -0,0 +1,50 @@
+package delete
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/pkg/patch"
+)
+
+// removeFinalizer removes the finalizer of the controller from the sandbox, the sandbox gone meanwhile is skipped.
+// The test operation fails the patch, if the finalizers are changed after the sandbox is got.
+func removeFinalizer(ctx context.Context, client kubeclient.Client, namespace, name string) error {
+	sandbox, err := client.Sandboxes(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	oldFinalizers := sandbox.GetFinalizers()
+	newFinalizers := make([]string, 0, len(oldFinalizers))
+	for _, f := range oldFinalizers {
+		if f != v1alpha1.FinalizerProtectBySandboxController {
+			newFinalizers = append(newFinalizers, f)
+		}
+	}
+	if len(newFinalizers) == len(oldFinalizers) {
+		return nil
+	}
+
+	patchBytes, err := patch.NewJSONPatch(
+		patch.WithTestOp("/metadata/finalizers", oldFinalizers),
+		patch.WithReplaceOp("/metadata/finalizers", newFinalizers),
+	).Payload()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Sandboxes(namespace).Patch(ctx, name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,190 @@
+package describe
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # Describe the sandbox 'my-sandbox'
+  {{ProgramName}} describe my-sandbox
+  # Describe the sandbox 'my-sandbox' without the events
+  {{ProgramName}} describe my-sandbox --show-events=false`
+
+	long = `Describe a sandbox: its spec, conditions, the resolved template, the tree of the child resources and the events.
+
+The child resources are found by the sandbox.io/uid label, their phases explain the stuck sandboxes,
+for example the waiting reasons of the containers and the progress of the volumes.
+The events of the sandbox and of all its child resources are merged into one timeline.`
+
+	timeFormat = "2006-01-02 15:04:05 -0700"
+)
+
+type describe struct {
+	showEvents bool
+}
+
+func NewDescribeSandboxCommand() *cobra.Command {
+	d := &describe{}
+
+	cmd := &cobra.Command{
+		Use:               "describe [Name]",
+		Short:             "Describe a sandbox with its child resources and events",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SandboxName,
+
+		RunE: d.Run,
+	}
+
+	cmd.Flags().BoolVar(&d.showEvents, "show-events", true, "Show the events of the sandbox and its child resources")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (d *describe) Run(cmd *cobra.Command, args []string) error {
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	sandbox, err := client.Sandboxes(namespace).Get(cmd.Context(), args[0], metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	templateSpec, templateStatus, err := resolveTemplate(cmd, client, sandbox)
+	if err != nil {
+		return err
+	}
+
+	w := printer.NewDescribeWriter(cmd.OutOrStdout())
+	w.Field(0, "Name", sandbox.Name)
+	w.Field(0, "Namespace", sandbox.Namespace)
+	w.Field(0, "Labels", printer.FormatLabels(sandbox.Labels))
+	w.Field(0, "Annotations", printer.FormatLabels(sandbox.Annotations))
+	w.Field(0, "Type", sandbox.Status.Type)
+	w.Field(0, "Status", printer.Status(sandbox))
+	w.Field(0, "TTL", printer.TTL(sandbox))
+	w.Field(0, "Created", sandbox.CreationTimestamp.Format(timeFormat))
+	if sandbox.DeletionTimestamp != nil {
+		w.Field(0, "Deleting Since", sandbox.DeletionTimestamp.Format(timeFormat))
+	}
+	w.Field(0, "Child Namespace", sandbox.Status.Namespace)
+
+	// The inline template is described in the template section.
+	spec := sandbox.Spec.DeepCopy()
+	spec.TemplateSpec = nil
+	if err = w.YAML(0, "Spec", spec); err != nil {
+		return err
+	}
+	w.Conditions(0, sandbox.Status.Conditions)
+	writeResult(w, sandbox.Status.Result)
+	writeComponents(w, sandbox.Status.Components)
+	writeVolumes(w, sandbox.Status.Volumes)
+
+	w.Write(0, "Template:\n")
+	w.Field(1, "Name", printer.Template(sandbox))
+	if templateStatus != "" {
+		w.Field(1, "Status", templateStatus)
+	}
+	if templateSpec != nil {
+		if err = w.TemplateSpec(1, templateSpec); err != nil {
+			return err
+		}
+	}
+
+	children, errs := findChildren(cmd.Context(), client.RESTConfig(), sandbox, templateSpec)
+	w.Write(0, "Resources:\n")
+	writeTree(w, 1, sandbox, buildTree(children))
+	for _, err := range errs {
+		w.Write(1, "Warning: %v\n", err)
+	}
+
+	if d.showEvents {
+		uids := map[types.UID]bool{sandbox.UID: true}
+		for _, child := range children {
+			uids[child.GetUID()] = true
+		}
+		events, err := findEvents(cmd.Context(), client.RESTConfig(), sandbox, uids)
+		if err != nil {
+			w.Field(0, "Events", fmt.Sprintf("<unable to list events: %v>", err))
+		} else {
+			writeEvents(w, 0, events)
+		}
+	}
+	return w.Flush()
+}
+
+// resolveTemplate returns the spec of the template of the sandbox and the status of the named template.
+// The deleted template is reported in the status, the sandbox is described without it.
+func resolveTemplate(cmd *cobra.Command, client kubeclient.Client, sandbox *v1alpha1.Sandbox) (*v1alpha1.SandboxTemplateSpec, string, error) {
+	if sandbox.Spec.Template == "" {
+		return sandbox.Spec.TemplateSpec, "", nil
+	}
+	t, err := client.SandboxTemplates().Get(cmd.Context(), sandbox.Spec.Template, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, "<not found>", nil
+	case apierrors.IsForbidden(err):
+		return nil, "<forbidden>", nil
+	case err != nil:
+		return nil, "", err
+	}
+	return &t.Spec, orNone(printer.TemplateStatus(t)), nil
+}
+
+func writeResult(w *printer.DescribeWriter, result *v1alpha1.SandboxResult) {
+	if result == nil {
+		return
+	}
+	w.Write(0, "Result:\n")
+	w.Field(1, "Phase", result.Phase)
+	if result.ExitCode != nil {
+		w.Field(1, "Exit Code", *result.ExitCode)
+	}
+	w.Field(1, "Message", result.Message)
+	if !result.FinishedAt.IsZero() {
+		w.Field(1, "Finished", result.FinishedAt.Format(timeFormat))
+	}
+	if result.ArtifactsClaimName != "" {
+		w.Field(1, "Artifacts Claim", result.ArtifactsClaimName)
+	}
+}
+
+func writeComponents(w *printer.DescribeWriter, components []v1alpha1.SandboxComponentStatus) {
+	if len(components) == 0 {
+		return
+	}
+	w.Write(0, "Components:\n")
+	w.Write(1, "Name\tType\tStatus\tReason\tMessage\n")
+	w.Write(1, "----\t----\t------\t------\t-------\n")
+	for _, c := range components {
+		w.Write(1, "%s\t%s\t%s\t%s\t%s\n", c.Name, orNone(string(c.Type)), orNone(string(c.Status)), orNone(c.Reason), c.Message)
+	}
+}
+
+func writeVolumes(w *printer.DescribeWriter, volumes []v1alpha1.SandboxVolumeStatus) {
+	if len(volumes) == 0 {
+		return
+	}
+	w.Write(0, "Volume Status:\n")
+	w.Write(1, "Name\tComponent\tResource\tPhase\tProgress\tReady\tMessage\n")
+	w.Write(1, "----\t---------\t--------\t-----\t--------\t-----\t-------\n")
+	for _, v := range volumes {
+		w.Write(1, "%s\t%s\t%s/%s\t%s\t%s\t%t\t%s\n",
+			v.Name, orNone(v.Component), v.Kind, v.ResourceName, orNone(v.Phase), orNone(v.Progress), v.Ready, v.Message)
+	}
+}
@@ -0,0 +1,84 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+)
+
+// findEvents returns the events of the sandbox and of its children ordered by time.
+// The events of the children, which are already deleted, are matched by the uid of the sandbox in their names.
+func findEvents(ctx context.Context, config *rest.Config, sandbox *v1alpha1.Sandbox, uids map[types.UID]bool) ([]corev1.Event, error) {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{sandbox.Namespace}
+	if namespace := common.GetChildNamespace(sandbox); namespace != sandbox.Namespace {
+		namespaces = append(namespaces, namespace)
+	}
+
+	var events []corev1.Event
+	for _, namespace := range namespaces {
+		list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range list.Items {
+			object := event.InvolvedObject
+			if uids[object.UID] || strings.Contains(object.Name, string(sandbox.UID)) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b corev1.Event) int {
+		return eventTime(a).Compare(eventTime(b))
+	})
+	return events, nil
+}
+
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// writeEvents writes the timeline of the events, the repeated events are reported with their count.
+func writeEvents(d *printer.DescribeWriter, level int, events []corev1.Event) {
+	if len(events) == 0 {
+		d.Field(level, "Events", "")
+		return
+	}
+	d.Write(level, "Events:\n")
+	d.Write(level+1, "Last Seen\tType\tReason\tObject\tMessage\n")
+	d.Write(level+1, "---------\t----\t------\t------\t-------\n")
+	for _, event := range events {
+		seen := duration.HumanDuration(time.Since(eventTime(event)))
+		if event.Count > 1 && !event.FirstTimestamp.IsZero() {
+			seen = fmt.Sprintf("%s (x%d over %s)", seen, event.Count, duration.HumanDuration(time.Since(event.FirstTimestamp.Time)))
+		}
+		object := event.InvolvedObject
+		d.Write(level+1, "%s\t%s\t%s\t%s/%s\t%s\n", seen, event.Type, event.Reason, object.Kind, object.Name, strings.Join(strings.Fields(event.Message), " "))
+	}
+}
@@ -0,0 +1,254 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+)
+
+var (
+	namespaceResource = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+	// childResources are the resources, which the built-in backends create for the sandboxes.
+	// The resources of the not installed virtualization are skipped.
+	childResources = []schema.GroupVersionResource{
+		{Group: "virtualization.deckhouse.io", Version: "v1alpha2", Resource: "virtualmachines"},
+		{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"},
+		{Version: "v1", Resource: "pods"},
+		{Group: "virtualization.deckhouse.io", Version: "v1alpha2", Resource: "virtualdisks"},
+		{Group: "cdi.kubevirt.io", Version: "v1beta1", Resource: "datavolumes"},
+		{Version: "v1", Resource: "persistentvolumeclaims"},
+	}
+
+	// kindOrder is the order of the children in the tree: the namespace, the workloads and then the volumes.
+	kindOrder = []string{"Namespace", "VirtualMachine", "VirtualMachineInstance", "Pod", "VirtualDisk", "DataVolume", "PersistentVolumeClaim"}
+)
+
+type node struct {
+	obj      *unstructured.Unstructured
+	children []*node
+}
+
+// findChildren finds the child resources of the sandbox by the label with its uid.
+// The resources of the unstructured sandboxes are listed by the kinds of the manifests of the template.
+// The errors of the resources, which cannot be listed, are returned along with the found children.
+func findChildren(ctx context.Context, config *rest.Config, sandbox *v1alpha1.Sandbox, spec *v1alpha1.SandboxTemplateSpec) ([]*unstructured.Unstructured, []error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	resources := slices.Clone(childResources)
+	var errs []error
+	if spec != nil && spec.UnstructuredSpec != nil {
+		manifestResources, err := manifestResources(config, spec.UnstructuredSpec)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, resource := range manifestResources {
+			if !slices.Contains(resources, resource) {
+				resources = append(resources, resource)
+			}
+		}
+	}
+
+	var children []*unstructured.Unstructured
+	seen := make(map[types.UID]bool)
+	selector := metav1.ListOptions{LabelSelector: v1alpha1.LabelSandboxUID + "=" + string(sandbox.UID)}
+	for _, resource := range resources {
+		list, err := client.Resource(resource).Namespace(common.GetChildNamespace(sandbox)).List(ctx, selector)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to list %s: %w", resource.GroupResource(), err))
+			}
+			continue
+		}
+		for i := range list.Items {
+			if !seen[list.Items[i].GetUID()] {
+				seen[list.Items[i].GetUID()] = true
+				children = append(children, &list.Items[i])
+			}
+		}
+	}
+
+	if sandbox.Status.Namespace != "" {
+		namespace, err := client.Resource(namespaceResource).Get(ctx, sandbox.Status.Namespace, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			children = append(children, namespace)
+		case !apierrors.IsNotFound(err):
+			errs = append(errs, fmt.Errorf("failed to get namespace %s: %w", sandbox.Status.Namespace, err))
+		}
+	}
+	return children, errs
+}
+
+func manifestResources(config *rest.Config, spec *v1alpha1.UnstructuredSpec) ([]schema.GroupVersionResource, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	var resources []schema.GroupVersionResource
+	for _, manifest := range spec.Manifests {
+		var typeMeta metav1.TypeMeta
+		if err = json.Unmarshal(manifest.Raw, &typeMeta); err != nil {
+			return resources, err
+		}
+		gvk := schema.FromAPIVersionAndKind(typeMeta.APIVersion, typeMeta.Kind)
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return resources, fmt.Errorf("failed to find the resource of %s: %w", gvk, err)
+		}
+		resources = append(resources, mapping.Resource)
+	}
+	return resources, nil
+}
+
+// buildTree arranges the children by the owner references, the children owned by the resources,
+// which are not the children of the sandbox, are the roots.
+func buildTree(children []*unstructured.Unstructured) []*node {
+	nodes := make(map[types.UID]*node, len(children))
+	for _, obj := range children {
+		nodes[obj.GetUID()] = &node{obj: obj}
+	}
+
+	var roots []*node
+	for _, obj := range children {
+		n := nodes[obj.GetUID()]
+		owner := ownerNode(obj, nodes)
+		if owner == nil {
+			roots = append(roots, n)
+			continue
+		}
+		owner.children = append(owner.children, n)
+	}
+
+	sortNodes(roots)
+	for _, n := range nodes {
+		sortNodes(n.children)
+	}
+	return roots
+}
+
+func ownerNode(obj *unstructured.Unstructured, nodes map[types.UID]*node) *node {
+	for _, ref := range obj.GetOwnerReferences() {
+		if owner, ok := nodes[ref.UID]; ok && ref.UID != obj.GetUID() {
+			return owner
+		}
+	}
+	return nil
+}
+
+func sortNodes(nodes []*node) {
+	order := func(kind string) int {
+		if i := slices.Index(kindOrder, kind); i >= 0 {
+			return i
+		}
+		return len(kindOrder)
+	}
+	slices.SortFunc(nodes, func(a, b *node) int {
+		if c := order(a.obj.GetKind()) - order(b.obj.GetKind()); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.obj.GetKind(), b.obj.GetKind()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.obj.GetName(), b.obj.GetName())
+	})
+}
+
+// writeTree writes the tree of the children under the sandbox.
+func writeTree(d *printer.DescribeWriter, level int, sandbox *v1alpha1.Sandbox, roots []*node) {
+	d.Write(level, "Resource\tPhase\tAge\n")
+	d.Write(level, "--------\t-----\t---\n")
+	d.Write(level, "%s/%s\t%s\t%s\n", v1alpha1.SandboxKind, sandbox.Name, orNone(printer.Status(sandbox)), printer.Age(sandbox.CreationTimestamp))
+	writeNodes(d, level, "", roots)
+}
+
+func writeNodes(d *printer.DescribeWriter, level int, prefix string, nodes []*node) {
+	for i, n := range nodes {
+		branch, indent := "├─ ", "│  "
+		if i == len(nodes)-1 {
+			branch, indent = "└─ ", "   "
+		}
+		d.Write(level, "%s%s%s/%s\t%s\t%s\n", prefix, branch, n.obj.GetKind(), n.obj.GetName(), phase(n.obj), printer.Age(n.obj.GetCreationTimestamp()))
+		writeNodes(d, level, prefix+indent, n.children)
+	}
+}
+
+// phase returns the phase of the resource, the reasons of the waiting containers of the pods
+// and the progress of the volumes are added, they explain the stuck sandboxes.
+func phase(obj *unstructured.Unstructured) string {
+	if obj.GetDeletionTimestamp() != nil {
+		return "Terminating"
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch obj.GetKind() {
+	case "Pod":
+		if reason := waitingReason(obj); reason != "" {
+			return fmt.Sprintf("%s (%s)", orNone(phase), reason)
+		}
+	case "DataVolume", "VirtualDisk":
+		if progress, _, _ := unstructured.NestedString(obj.Object, "status", "progress"); progress != "" && phase != "" {
+			return fmt.Sprintf("%s %s", phase, progress)
+		}
+	}
+	if phase != "" {
+		return phase
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if ok && condition["type"] == "Ready" {
+			if condition["status"] == string(metav1.ConditionTrue) {
+				return "Ready"
+			}
+			return "NotReady"
+		}
+	}
+	return orNone("")
+}
+
+func waitingReason(pod *unstructured.Unstructured) string {
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", field)
+		for _, s := range statuses {
+			status, ok := s.(map[string]any)
+			if !ok {
+				continue
+			}
+			if reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/config"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/terminal"
+)
+
+const (
+	example = `  # Run 'date' in the sandbox 'my-sandbox':
+  {{ProgramName}} exec my-sandbox -- date
+  # Run the interactive shell in the component 'app' of the multi-component sandbox 'my-sandbox':
+  {{ProgramName}} exec my-sandbox --component app -it -- sh
+  # Pass the file to the command:
+  {{ProgramName}} exec my-sandbox -i -- sh -c 'cat > /tmp/script.sh' < script.sh
+  # Run the attach command of the config file, for example the login shell:
+  {{ProgramName}} exec my-sandbox -it`
+
+	long = `Execute a command in a sandbox.
+
+Only the pod sandboxes are supported. The command exits with the exit code of the remote command.
+Without the command the attachCommand of the config file is executed.`
+)
+
+type execCommand struct {
+	component string
+	stdin     bool
+	tty       bool
+}
+
+func NewExecSandboxCommand() *cobra.Command {
+	e := &execCommand{}
+
+	cmd := &cobra.Command{
+		Use:               "exec [Name] [-- COMMAND [args...]]",
+		Short:             "Execute a command in a sandbox",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.SandboxName,
+		RunE:              e.Run,
+	}
+
+	cmd.Flags().StringVar(&e.component, "component", "", "Component of the multi-component sandbox to execute the command in")
+	cmd.Flags().BoolVarP(&e.stdin, "stdin", "i", false, "Pass stdin to the command")
+	cmd.Flags().BoolVarP(&e.tty, "tty", "t", false, "Allocate the terminal for the command")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (e *execCommand) Run(cmd *cobra.Command, args []string) error {
+	if dash := cmd.ArgsLenAtDash(); dash != -1 && dash != 1 {
+		return fmt.Errorf("expected the sandbox name before --, got %v", args[:dash])
+	}
+	name, command := args[0], args[1:]
+	if len(command) == 0 {
+		command = config.FromContext(cmd.Context()).AttachCommand
+	}
+	if len(command) == 0 {
+		return fmt.Errorf("the command is required, pass it after -- or set attachCommand in the config file")
+	}
+
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	tty := e.tty && term.IsTerminal(int(os.Stdin.Fd()))
+	if e.tty && !tty {
+		cmd.PrintErrln("Unable to use a TTY - input is not a terminal or the right kind of file")
+	}
+
+	stream, err := client.Sandboxes(namespace).Exec(name, &subv1alpha1.Exec{
+		Component: e.component,
+		Command:   command,
+		Stdin:     e.stdin,
+		Stdout:    true,
+		Stderr:    !tty,
+		TTY:       tty,
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := kubeclient.StreamOptions{
+		Out: os.Stdout,
+		Err: os.Stderr,
+	}
+	if e.stdin {
+		opts.In = os.Stdin
+	}
+	if tty {
+		fd := int(os.Stdin.Fd())
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("make raw terminal failed: %w", err)
+		}
+		defer term.Restore(fd, state)
+
+		queue := terminal.NewSizeQueue(int(os.Stdout.Fd()))
+		defer queue.Stop()
+		opts.SizeQueue = queue
+	}
+
+	return stream.Stream(opts)
+}
@@ -0,0 +1,81 @@
+package list
+
+import (
+	"slices"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	getExample = `  # Get the sandbox
+  {{ProgramName}} get my-sandbox
+  # Get the sandbox in yaml
+  {{ProgramName}} get my-sandbox -o yaml
+  # Watch the status of the sandbox
+  {{ProgramName}} get my-sandbox -w`
+)
+
+type get struct {
+	output string
+	watch  bool
+}
+
+func NewGetSandboxCommand() *cobra.Command {
+	g := &get{}
+
+	cmd := &cobra.Command{
+		Use:               "get [Name...]",
+		Short:             "Get sandboxes",
+		Example:           getExample,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.SandboxNames,
+
+		RunE: g.Run,
+	}
+
+	cmd.Flags().StringVarP(&g.output, "output", "o", "", printer.OutputUsage)
+	cmd.Flags().BoolVarP(&g.watch, "watch", "w", false, "Watch the changes after getting the sandboxes")
+
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.OutputFormats)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (g *get) Run(cmd *cobra.Command, args []string) error {
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	p, err := printer.New(cmd.OutOrStdout(), g.output, false, client.RESTConfig())
+	if err != nil {
+		return err
+	}
+
+	if g.watch {
+		return watch(cmd.Context(), client, namespace, "", func(sandbox *v1alpha1.Sandbox) bool {
+			return slices.Contains(args, sandbox.Name)
+		}, p)
+	}
+
+	var sandboxes []v1alpha1.Sandbox
+	for _, name := range args {
+		sandbox, err := client.Sandboxes(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		sandboxes = append(sandboxes, *sandbox)
+	}
+	if len(sandboxes) == 1 {
+		return p.Print(cmd.Context(), &sandboxes[0])
+	}
+	return p.PrintList(cmd.Context(), sandboxes)
+}
@@ -0,0 +1,88 @@
+package list
+
+import (
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # List the sandboxes in the current namespace
+  {{ProgramName}} list
+  # List the sandboxes in all namespaces with the nodes
+  {{ProgramName}} ls -A -o wide
+  # List the sandboxes with the label and watch the changes
+  {{ProgramName}} list -l team=qa -w
+  # Print the names of the sandboxes
+  {{ProgramName}} list -o jsonpath='{.items[*].metadata.name}'`
+)
+
+type list struct {
+	allNamespaces bool
+	selector      string
+	output        string
+	watch         bool
+}
+
+func NewListSandboxCommand() *cobra.Command {
+	l := &list{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List sandboxes",
+		Example: example,
+		Args:    cobra.NoArgs,
+
+		RunE: l.Run,
+	}
+
+	cmd.Flags().BoolVarP(&l.allNamespaces, "all-namespaces", "A", false, "List the sandboxes across all namespaces")
+	cmd.Flags().StringVarP(&l.selector, "selector", "l", "", "Label selector to filter on, for example -l key1=value1,key2=value2")
+	cmd.Flags().StringVarP(&l.output, "output", "o", "", printer.OutputUsage)
+	cmd.Flags().BoolVarP(&l.watch, "watch", "w", false, "Watch the changes after listing the sandboxes")
+
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.OutputFormats)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (l *list) Run(cmd *cobra.Command, _ []string) error {
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if l.allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	p, err := printer.New(cmd.OutOrStdout(), l.output, l.allNamespaces, client.RESTConfig())
+	if err != nil {
+		return err
+	}
+
+	if l.watch {
+		return watch(cmd.Context(), client, namespace, l.selector, func(*v1alpha1.Sandbox) bool { return true }, p)
+	}
+
+	sandboxes, err := client.Sandboxes(namespace).List(cmd.Context(), metav1.ListOptions{LabelSelector: l.selector})
+	if err != nil {
+		return err
+	}
+	if len(sandboxes.Items) == 0 && p.IsTable() {
+		if l.allNamespaces {
+			cmd.PrintErrln("No sandboxes found.")
+		} else {
+			cmd.PrintErrf("No sandboxes found in %s namespace.\n", namespace)
+		}
+		return nil
+	}
+	sortSandboxes(sandboxes.Items)
+	return p.PrintList(cmd.Context(), sandboxes.Items)
+}
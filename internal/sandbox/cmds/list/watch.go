@@ -0,0 +1,109 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/generated/informers/externalversions"
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+)
+
+// watch prints the sandboxes and then their changes until the context is canceled.
+// The changes are delivered by the informer, the sandboxes not matching the filter are skipped.
+func watch(ctx context.Context, client kubeclient.Client, namespace, selector string, filter func(*v1alpha1.Sandbox) bool, p *printer.Printer) error {
+	factory := externalversions.NewSharedInformerFactoryWithOptions(client.Clientset(), 0,
+		externalversions.WithNamespace(namespace),
+		externalversions.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		}),
+	)
+	informer := factory.Sandbox().V1alpha1().Sandboxes()
+	// The informer is requested before the start, so it is started by the factory.
+	sharedInformer := informer.Informer()
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	if !cache.WaitForCacheSync(ctx.Done(), sharedInformer.HasSynced) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to sync the sandboxes")
+	}
+
+	// The handler is added before the sandboxes are listed, so no change is missed.
+	changes := make(chan *v1alpha1.Sandbox, 64)
+	send := func(obj any) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		sandbox, ok := obj.(*v1alpha1.Sandbox)
+		if !ok || !filter(sandbox) {
+			return
+		}
+		select {
+		case changes <- sandbox:
+		case <-ctx.Done():
+		}
+	}
+	_, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			if !isInInitialList {
+				send(obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			if oldObj.(*v1alpha1.Sandbox).ResourceVersion != newObj.(*v1alpha1.Sandbox).ResourceVersion {
+				send(newObj)
+			}
+		},
+		DeleteFunc: send,
+	})
+	if err != nil {
+		return err
+	}
+
+	list, err := informer.Lister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var sandboxes []v1alpha1.Sandbox
+	for _, sandbox := range list {
+		if filter(sandbox) {
+			sandboxes = append(sandboxes, *sandbox)
+		}
+	}
+	sortSandboxes(sandboxes)
+	for i := range sandboxes {
+		if err = p.Print(ctx, &sandboxes[i]); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sandbox := <-changes:
+			if err = p.Print(ctx, sandbox); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sortSandboxes(sandboxes []v1alpha1.Sandbox) {
+	slices.SortFunc(sandboxes, func(a, b v1alpha1.Sandbox) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
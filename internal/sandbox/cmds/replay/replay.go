@@ -0,0 +1,123 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # Play back the session of the sandbox 'my-sandbox':
+  {{ProgramName}} replay my-sandbox 20250101T120000Z-abcde
+  # Play back twice as fast, shortening the pauses to 2 seconds:
+  {{ProgramName}} replay my-sandbox 20250101T120000Z-abcde --speed 2 --idle-limit 2s
+  # Save the recording in the asciinema v2 format:
+  {{ProgramName}} replay my-sandbox 20250101T120000Z-abcde --raw > session.cast`
+
+	long = `Play back a recorded attach session of a sandbox in the terminal.
+
+Use the sessions command to list the recorded sessions.`
+)
+
+type replay struct {
+	speed     float64
+	idleLimit time.Duration
+	raw       bool
+}
+
+func NewReplaySandboxCommand() *cobra.Command {
+	r := &replay{}
+
+	cmd := &cobra.Command{
+		Use:               "replay [Name] [Session]",
+		Short:             "Play back a recorded attach session",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.SandboxName,
+		RunE:              r.Run,
+	}
+
+	cmd.Flags().Float64Var(&r.speed, "speed", 1, "Playback speed multiplier")
+	cmd.Flags().DurationVar(&r.idleLimit, "idle-limit", 0, "Limit the pauses between the events, not limited if 0")
+	cmd.Flags().BoolVar(&r.raw, "raw", false, "Print the recording in the asciinema v2 format instead of playing it")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (r *replay) Run(cmd *cobra.Command, args []string) error {
+	name, session := args[0], args[1]
+	if r.speed <= 0 {
+		return fmt.Errorf("speed must be positive")
+	}
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	recording, err := client.Sandboxes(namespace).Recording(cmd.Context(), name, session)
+	if err != nil {
+		return err
+	}
+	defer recording.Close()
+
+	if r.raw {
+		_, err = io.Copy(cmd.OutOrStdout(), recording)
+		return err
+	}
+
+	return r.play(cmd, recording)
+}
+
+// play writes the output events of the asciinema v2 recording keeping the recorded pauses.
+func (r replay) play(cmd *cobra.Command, recording io.Reader) error {
+	scanner := bufio.NewScanner(recording)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	// The first line is the header.
+	if !scanner.Scan() {
+		return fmt.Errorf("recording is empty")
+	}
+
+	var last float64
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+		if len(event) != 3 {
+			continue
+		}
+		elapsed, _ := event[0].(float64)
+		code, _ := event[1].(string)
+		data, _ := event[2].(string)
+		if code != "o" {
+			continue
+		}
+
+		pause := time.Duration((elapsed - last) / r.speed * float64(time.Second))
+		if r.idleLimit > 0 && pause > r.idleLimit {
+			pause = r.idleLimit
+		}
+		last = elapsed
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-time.After(pause):
+		}
+		if _, err := io.WriteString(cmd.OutOrStdout(), data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,251 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/attach"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/waiter"
+)
+
+const (
+	example = `  # Run a throwaway shell in the ubuntu image, the sandbox is deleted after the session:
+  {{ProgramName}} run --image ubuntu:24.04 --rm -it
+  # Run the sandbox 'my-sandbox' with the resources, the environment and the volume:
+  {{ProgramName}} run my-sandbox --image python:3.12 --cpu 2 --memory 4Gi --env DEBUG=1 --volume data:10Gi:/data
+  # Run the sandbox from the template and attach to it:
+  {{ProgramName}} run --template my-template -it
+  # Run the command in the image:
+  {{ProgramName}} run --image busybox -- sleep 3600`
+
+	long = `Create a sandbox from an image or a template, wait until it is ready and attach to it.
+
+The sandbox from the image is created with the inline template of the pod with a single container.
+Without a command the container keeps the default command of the image with the terminal, so the shells of the images stay running.
+With --rm the sandbox is deleted, when the session ends. The exit code of the shell is the exit code of the command.`
+
+	containerName = "main"
+	volumeSuffix  = "-volume"
+)
+
+type run struct {
+	image       string
+	template    string
+	cpu         string
+	memory      string
+	env         []string
+	volumes     []string
+	ttl         time.Duration
+	timeout     time.Duration
+	remove      bool
+	interactive bool
+	tty         bool
+}
+
+func NewRunSandboxCommand() *cobra.Command {
+	r := &run{}
+
+	cmd := &cobra.Command{
+		Use:               "run [Name] [--image IMAGE | --template TEMPLATE] [-- COMMAND [args...]]",
+		Short:             "Run a sandbox from an image or a template in one step",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+
+		RunE: r.Run,
+	}
+
+	cmd.Flags().StringVar(&r.image, "image", "", "Image of the container of the sandbox")
+	cmd.Flags().StringVar(&r.template, "template", "", "Template name, the alternative to --image")
+	cmd.Flags().StringVar(&r.cpu, "cpu", "", "CPU requests and limits of the container, for example 500m")
+	cmd.Flags().StringVar(&r.memory, "memory", "", "Memory requests and limits of the container, for example 1Gi")
+	cmd.Flags().StringArrayVarP(&r.env, "env", "e", nil, "Environment variable of the container in the form KEY=VALUE")
+	cmd.Flags().StringArrayVar(&r.volumes, "volume", nil, "Persistent volume in the form NAME:SIZE:PATH, for example data:10Gi:/data")
+	cmd.Flags().DurationVarP(&r.ttl, "ttl", "l", 1*time.Hour, "Sandbox TTL")
+	cmd.Flags().DurationVar(&r.timeout, "timeout", 10*time.Minute, "Time to wait for the sandbox to get ready, zero means no timeout")
+	cmd.Flags().BoolVar(&r.remove, "rm", false, "Delete the sandbox, when the session ends")
+	cmd.Flags().BoolVarP(&r.interactive, "stdin", "i", false, "Attach to the sandbox, when it is ready")
+	cmd.Flags().BoolVarP(&r.tty, "tty", "t", false, "Allocate the terminal, it is implied by --stdin")
+	cmd.MarkFlagsMutuallyExclusive("image", "template")
+	cmd.MarkFlagsOneRequired("image", "template")
+	common.SetDryRun(cmd.Flags())
+
+	_ = cmd.RegisterFlagCompletionFunc("template", completion.TemplateNames)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (r *run) Run(cmd *cobra.Command, args []string) error {
+	var name string
+	var command []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		command = args[dash:]
+		args = args[:dash]
+	}
+	switch {
+	case len(args) > 1:
+		return fmt.Errorf("expected at most one sandbox name, got %d", len(args))
+	case len(args) == 1:
+		name = args[0]
+	}
+
+	attachTo := r.interactive || r.tty
+	if r.remove && !attachTo {
+		return fmt.Errorf("--rm requires attaching to the sandbox with -i or -t")
+	}
+
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	sandbox := common.NewSandbox(name, "run-", namespace, r.ttl)
+	if r.template != "" {
+		if len(command) > 0 || r.cpu != "" || r.memory != "" || len(r.env) > 0 || len(r.volumes) > 0 {
+			return fmt.Errorf("the command, --cpu, --memory, --env and --volume are supported only with --image")
+		}
+		sandbox.Spec.Template = r.template
+	} else {
+		sandbox.Spec.TemplateSpec, err = r.templateSpec(command)
+		if err != nil {
+			return err
+		}
+	}
+
+	if common.IsDryRun() {
+		sandbox, err = client.Sandboxes(namespace).Create(cmd.Context(), sandbox, metav1.CreateOptions{DryRun: common.GetDryRun()})
+		if err != nil {
+			return err
+		}
+		bytes, err := yaml.Marshal(sandbox)
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(bytes))
+		return nil
+	}
+
+	sandbox, err = client.Sandboxes(namespace).Create(cmd.Context(), sandbox, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	name = sandbox.Name
+	cmd.PrintErrf("sandbox/%s created\n", name)
+
+	if r.remove {
+		defer func() {
+			// The sandbox is deleted even if the command is interrupted.
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(cmd.Context()), time.Minute)
+			defer cancel()
+			if err := client.Sandboxes(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+				cmd.PrintErrf("Failed to delete sandbox %s: %v\n", name, err)
+				return
+			}
+			cmd.PrintErrf("sandbox/%s deleted\n", name)
+		}()
+	}
+
+	if err = waiter.Wait(cmd.Context(), client, namespace, name, waiter.ConditionReady, r.timeout, cmd.ErrOrStderr()); err != nil {
+		return err
+	}
+	if !attachTo {
+		cmd.Printf("Sandbox %s is ready.\n", name)
+		return nil
+	}
+
+	return attach.IgnoreInterrupt(attach.Connect(name, namespace, "", "", client))
+}
+
+// templateSpec returns the inline template of the pod with the single container of the image.
+func (r *run) templateSpec(command []string) (*v1alpha1.SandboxTemplateSpec, error) {
+	container := corev1.Container{
+		Name:    containerName,
+		Image:   r.image,
+		Command: command,
+	}
+	if len(command) == 0 {
+		// The default shell of the image exits without the terminal.
+		container.Stdin = true
+		container.TTY = true
+	}
+
+	resources := corev1.ResourceList{}
+	for resourceName, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: r.cpu, corev1.ResourceMemory: r.memory} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", resourceName, value, err)
+		}
+		resources[resourceName] = quantity
+	}
+	if len(resources) > 0 {
+		container.Resources = corev1.ResourceRequirements{
+			Requests: resources,
+			Limits:   resources,
+		}
+	}
+
+	for _, env := range r.env {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", env)
+		}
+		container.Env = append(container.Env, corev1.EnvVar{Name: key, Value: value})
+	}
+
+	spec := &v1alpha1.SandboxTemplateSpec{
+		PodSpec: &corev1.PodSpec{},
+	}
+	for _, volume := range r.volumes {
+		parts := strings.SplitN(volume, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || !strings.HasPrefix(parts[2], "/") {
+			return nil, fmt.Errorf("invalid volume %q, expected NAME:SIZE:PATH", volume)
+		}
+		size, err := resource.ParseQuantity(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of the volume %q: %w", volume, err)
+		}
+
+		spec.Volumes = append(spec.Volumes, v1alpha1.SandboxVolumeSpec{
+			Name: parts[0],
+			PVCSpec: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+				},
+			},
+		})
+		// The pod volume refers to the volume of the template by the claim name,
+		// the claim name is replaced with the name of the claim of the sandbox.
+		spec.PodSpec.Volumes = append(spec.PodSpec.Volumes, corev1.Volume{
+			Name: parts[0] + volumeSuffix,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: parts[0]},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      parts[0] + volumeSuffix,
+			MountPath: parts[2],
+		})
+	}
+
+	spec.PodSpec.Containers = []corev1.Container{container}
+	return spec, nil
+}
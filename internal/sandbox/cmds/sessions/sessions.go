@@ -0,0 +1,128 @@
+package sessions
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # List the recorded attach sessions of the sandbox 'my-sandbox':
+  {{ProgramName}} sessions my-sandbox
+  # List the live attach sessions of the sandbox 'my-sandbox':
+  {{ProgramName}} sessions my-sandbox --live`
+
+	long = `List the recorded attach sessions of a sandbox.
+
+The recordings of the sandbox are listed while it exists, the recordings of the deleted sandbox are not served,
+even if the sandbox of the same name is created. Use the replay command to play a session back.
+Use --live to list the sessions, which are in progress, and attach --observe to watch them.`
+)
+
+type sessions struct {
+	live bool
+}
+
+func NewSessionsSandboxCommand() *cobra.Command {
+	s := &sessions{}
+
+	cmd := &cobra.Command{
+		Use:               "sessions [Name]",
+		Short:             "List recorded or live attach sessions of a sandbox",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SandboxName,
+		RunE:              s.Run,
+	}
+
+	cmd.Flags().BoolVar(&s.live, "live", false, "List the live sessions instead of the recorded ones")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (s *sessions) Run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if s.live {
+		return s.printLive(cmd, client.Sandboxes(namespace), name)
+	}
+
+	recordings, err := client.Sandboxes(namespace).Recordings(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+	if len(recordings.Items) == 0 {
+		cmd.Printf("No recorded sessions found for sandbox %s.\n", name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SESSION\tUSER\tCOMPONENT\tSTARTED\tDURATION\tSIZE")
+	for _, recording := range recordings.Items {
+		component := recording.Component
+		if component == "" {
+			component = "<none>"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			recording.Session,
+			recording.User,
+			component,
+			recording.StartTime.Format(time.RFC3339),
+			duration.HumanDuration(recording.EndTime.Sub(recording.StartTime.Time)),
+			resource.NewQuantity(recording.Size, resource.BinarySI).String(),
+		)
+	}
+	return w.Flush()
+}
+
+func (s *sessions) printLive(cmd *cobra.Command, sandboxes kubeclient.SandboxInterface, name string) error {
+	live, err := sandboxes.Sessions(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+	if len(live.Items) == 0 {
+		cmd.Printf("No live sessions found for sandbox %s.\n", name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SESSION\tCOMPONENT\tDRIVER\tOBSERVERS\tAGE")
+	for _, session := range live.Items {
+		component := session.Component
+		if component == "" {
+			component = "<none>"
+		}
+		driver := session.Driver
+		if driver == "" {
+			driver = "<none>"
+		}
+		observers := strings.Join(session.Observers, ",")
+		if observers == "" {
+			observers = "<none>"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			session.Session,
+			component,
+			driver,
+			observers,
+			duration.HumanDuration(time.Since(session.StartTime.Time)),
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,65 @@
+package templates
+
+import (
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	createExample = `  # Create the templates from the file
+  {{ProgramName}} templates create -f my-template.yaml
+  # Create the template from the standard input
+  {{ProgramName}} templates init my-template --type pod | {{ProgramName}} templates create -f -`
+)
+
+type create struct {
+	filename string
+}
+
+func newCreateCommand() *cobra.Command {
+	c := &create{}
+
+	cmd := &cobra.Command{
+		Use:     "create -f FILE",
+		Short:   "Create sandbox templates from a file",
+		Example: createExample,
+		Args:    cobra.NoArgs,
+
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.filename, "filename", "f", "", "The yaml or json file with the templates, - is the standard input")
+	_ = cmd.MarkFlagRequired("filename")
+	common.SetDryRun(cmd.Flags())
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (c *create) Run(cmd *cobra.Command, _ []string) error {
+	templates, err := readTemplates(cmd, c.filename)
+	if err != nil {
+		return err
+	}
+	client, _, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	suffix := ""
+	if common.IsDryRun() {
+		suffix = " (dry run)"
+	}
+	for _, t := range templates {
+		created, err := client.SandboxTemplates().Create(cmd.Context(), t, metav1.CreateOptions{DryRun: common.GetDryRun()})
+		if err != nil {
+			return err
+		}
+		cmd.Printf("sandboxtemplate/%s created%s\n", created.Name, suffix)
+	}
+	return nil
+}
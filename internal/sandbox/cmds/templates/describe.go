@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	describeExample = `  # Describe the template 'my-template'
+  {{ProgramName}} templates describe my-template`
+
+	describeLong = `Describe a sandbox template: its status, spec, volumes and the sandboxes using it.
+
+The sandboxes are looked up in all namespaces, if the user is not allowed to list them, only in the current namespace.`
+)
+
+func newDescribeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "describe [Name]",
+		Short:             "Describe a sandbox template",
+		Example:           describeExample,
+		Long:              describeLong,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.TemplateName,
+
+		RunE: describe,
+	}
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func describe(cmd *cobra.Command, args []string) error {
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	t, err := client.SandboxTemplates().Get(cmd.Context(), args[0], metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	sandboxes, err := client.Sandboxes(metav1.NamespaceAll).List(cmd.Context(), metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		sandboxes, err = client.Sandboxes(namespace).List(cmd.Context(), metav1.ListOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	var using []v1alpha1.Sandbox
+	for _, sandbox := range sandboxes.Items {
+		if sandbox.Spec.Template == t.Name {
+			using = append(using, sandbox)
+		}
+	}
+	slices.SortFunc(using, func(a, b v1alpha1.Sandbox) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	d := printer.NewDescribeWriter(cmd.OutOrStdout())
+	d.Field(0, "Name", t.Name)
+	d.Field(0, "Labels", printer.FormatLabels(t.Labels))
+	d.Field(0, "Type", t.Status.Type)
+	d.Field(0, "Status", printer.TemplateStatus(t))
+	d.Field(0, "Created", t.CreationTimestamp.Format("2006-01-02 15:04:05 -0700"))
+	d.Conditions(0, t.Status.Conditions)
+	if err = d.TemplateSpec(0, &t.Spec); err != nil {
+		return err
+	}
+
+	if len(using) == 0 {
+		d.Field(0, "Sandboxes", "")
+	} else {
+		d.Write(0, "Sandboxes:\n")
+		d.Write(1, "Namespace\tName\tStatus\tTTL\tAge\n")
+		d.Write(1, "---------\t----\t------\t---\t---\n")
+		for _, sandbox := range using {
+			d.Write(1, "%s\t%s\t%s\t%s\t%s\n",
+				sandbox.Namespace, sandbox.Name, printer.Status(&sandbox), printer.TTL(&sandbox), printer.Age(sandbox.CreationTimestamp))
+		}
+	}
+	return d.Flush()
+}
@@ -0,0 +1,181 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	gotemplate "text/template"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	initExample = `  # Scaffold the template of the pod
+  {{ProgramName}} templates init my-template > my-template.yaml
+  # Scaffold the template of the kubevirt virtual machine instance
+  {{ProgramName}} templates init my-vm --type kubevirt > my-vm.yaml
+  # Scaffold the template of the dvp virtual machine
+  {{ProgramName}} templates init my-vm --type dvp > my-vm.yaml`
+
+	initLong = `Scaffold a starter sandbox template and print it.
+
+The template is a starting point: edit it, check it with validate and create it with create.`
+)
+
+const (
+	typePod      = "pod"
+	typeKubevirt = "kubevirt"
+	typeDVP      = "dvp"
+)
+
+var scaffolds = map[string]string{
+	typePod: `apiVersion: sandbox.io/v1alpha1
+kind: SandboxTemplate
+metadata:
+  name: {{.Name}}
+spec:
+  podSpec:
+    containers:
+      - name: main
+        image: ubuntu:24.04
+        # The shell keeps running with the terminal, attach to it.
+        stdin: true
+        tty: true
+        resources:
+          requests:
+            cpu: 500m
+            memory: 512Mi
+          limits:
+            cpu: "1"
+            memory: 1Gi
+        volumeMounts:
+          - name: data-volume
+            mountPath: /data
+    volumes:
+      # The claim name refers to the volume of the template.
+      - name: data-volume
+        persistentVolumeClaim:
+          claimName: data
+  volumes:
+    - name: data
+      pvcSpec:
+        accessModes:
+          - ReadWriteOnce
+        resources:
+          requests:
+            storage: 1Gi
+`,
+	typeKubevirt: `apiVersion: sandbox.io/v1alpha1
+kind: SandboxTemplate
+metadata:
+  name: {{.Name}}
+spec:
+  kubevirtVMISpec:
+    domain:
+      cpu:
+        cores: 1
+      memory:
+        guest: 1Gi
+      devices:
+        disks:
+          - name: root
+            disk:
+              bus: virtio
+          - name: cloudinit
+            disk:
+              bus: virtio
+    terminationGracePeriodSeconds: 10
+    volumes:
+      - name: root
+        containerDisk:
+          image: quay.io/containerdisks/ubuntu:24.04
+      # The SSH keys of the sandbox are added to the cloud-init user data, see create --ssh-key.
+      - name: cloudinit
+        cloudInitNoCloud:
+          userData: |
+            #cloud-config
+            users:
+              - name: ubuntu
+                shell: /bin/bash
+                sudo: ALL=(ALL) NOPASSWD:ALL
+`,
+	typeDVP: `apiVersion: sandbox.io/v1alpha1
+kind: SandboxTemplate
+metadata:
+  name: {{.Name}}
+spec:
+  dvpVMSpec:
+    virtualMachineClassName: generic
+    cpu:
+      cores: 1
+      coreFraction: 100%
+    memory:
+      size: 1Gi
+    blockDeviceRefs:
+      # The name refers to the volume of the template.
+      - kind: VirtualDisk
+        name: root
+    # The SSH keys of the sandbox are added to the cloud-init user data, see create --ssh-key.
+    provisioning:
+      type: UserData
+      userData: |
+        #cloud-config
+        users:
+          - name: ubuntu
+            shell: /bin/bash
+            sudo: ALL=(ALL) NOPASSWD:ALL
+    runPolicy: AlwaysOnUnlessStoppedManually
+    terminationGracePeriodSeconds: 10
+  volumes:
+    - name: root
+      virtualDiskSpec:
+        dataSource:
+          type: ObjectRef
+          objectRef:
+            kind: ClusterVirtualImage
+            name: ubuntu-24-04
+        persistentVolumeClaim:
+          size: 10Gi
+`,
+}
+
+type initTemplate struct {
+	templateType string
+}
+
+func newInitCommand() *cobra.Command {
+	i := &initTemplate{}
+
+	cmd := &cobra.Command{
+		Use:               "init [Name]",
+		Short:             "Scaffold a starter sandbox template",
+		Example:           initExample,
+		Long:              initLong,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+
+		RunE: i.Run,
+	}
+
+	cmd.Flags().StringVar(&i.templateType, "type", typePod, "Type of the template: pod, kubevirt or dvp")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]string{typePod, typeKubevirt, typeDVP}, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (i *initTemplate) Run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return fmt.Errorf("invalid template name %q: %s", name, strings.Join(msgs, ", "))
+	}
+	scaffold, ok := scaffolds[strings.ToLower(i.templateType)]
+	if !ok {
+		return fmt.Errorf("unknown template type %q, allowed types: %s, %s, %s", i.templateType, typePod, typeKubevirt, typeDVP)
+	}
+
+	return gotemplate.Must(gotemplate.New(i.templateType).Parse(scaffold)).Execute(cmd.OutOrStdout(), struct{ Name string }{name})
+}
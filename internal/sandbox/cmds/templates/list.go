@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	listExample = `  # List the templates
+  {{ProgramName}} templates list
+  # List the templates with the label in yaml
+  {{ProgramName}} templates ls -l team=qa -o yaml`
+)
+
+type list struct {
+	selector string
+	output   string
+}
+
+func newListCommand() *cobra.Command {
+	l := &list{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List sandbox templates with their type and status",
+		Example: listExample,
+		Args:    cobra.NoArgs,
+
+		RunE: l.Run,
+	}
+
+	cmd.Flags().StringVarP(&l.selector, "selector", "l", "", "Label selector to filter on, for example -l key1=value1,key2=value2")
+	cmd.Flags().StringVarP(&l.output, "output", "o", "", printer.OutputUsage)
+
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.OutputFormats)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (l *list) Run(cmd *cobra.Command, _ []string) error {
+	client, _, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	p, err := printer.New(cmd.OutOrStdout(), l.output, false, client.RESTConfig())
+	if err != nil {
+		return err
+	}
+
+	templates, err := client.SandboxTemplates().List(cmd.Context(), metav1.ListOptions{LabelSelector: l.selector})
+	if err != nil {
+		return err
+	}
+	if len(templates.Items) == 0 && p.IsTable() {
+		cmd.PrintErrln("No sandbox templates found.")
+		return nil
+	}
+	slices.SortFunc(templates.Items, func(a, b v1alpha1.SandboxTemplate) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return p.PrintTemplateList(templates.Items)
+}
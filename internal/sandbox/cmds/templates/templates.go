@@ -0,0 +1,101 @@
+package templates
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # List the templates
+  {{ProgramName}} templates list
+  # Scaffold the template of the pod, validate and create it
+  {{ProgramName}} templates init my-template --type pod > my-template.yaml
+  {{ProgramName}} templates validate -f my-template.yaml
+  {{ProgramName}} templates create -f my-template.yaml
+  # Describe the template and the sandboxes using it
+  {{ProgramName}} templates describe my-template`
+)
+
+func NewTemplatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "templates",
+		Aliases: []string{"template", "sbt"},
+		Short:   "Manage sandbox templates",
+		Example: example,
+		Args:    cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newListCommand(),
+		newDescribeCommand(),
+		newCreateCommand(),
+		newValidateCommand(),
+		newInitCommand(),
+	)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+// readTemplates reads the templates from the yaml or json file, "-" is the standard input.
+// The file may contain several documents, the documents of other kinds, such as the sandboxes of the examples, are skipped.
+func readTemplates(cmd *cobra.Command, filename string) ([]*v1alpha1.SandboxTemplate, error) {
+	var in io.Reader
+	if filename == "-" {
+		in = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var templates []*v1alpha1.SandboxTemplate
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(in))
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		object := &metav1.PartialObjectMetadata{}
+		if err = yaml.Unmarshal(doc, object); err != nil {
+			return nil, fmt.Errorf("failed to decode the document %d of %s: %w", i, filename, err)
+		}
+		if object.Kind != v1alpha1.SandboxTemplateKind || object.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+			cmd.PrintErrf("Skipping %s %s of the document %d, it is not a sandbox template.\n", object.Kind, object.Name, i)
+			continue
+		}
+
+		// The unknown fields are the typos, they would be dropped by the apiserver silently.
+		t := &v1alpha1.SandboxTemplate{}
+		if err = yaml.UnmarshalStrict(doc, t); err != nil {
+			return nil, fmt.Errorf("failed to decode the template %s of the document %d of %s: %w", object.Name, i, filename, err)
+		}
+		templates = append(templates, t)
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no templates found in %s", filename)
+	}
+	return templates, nil
+}
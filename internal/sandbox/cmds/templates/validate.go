@@ -0,0 +1,192 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	validateExample = `  # Validate the templates of the file locally and with the server-side dry-run
+  {{ProgramName}} templates validate -f my-template.yaml
+  # Validate the templates without the cluster
+  {{ProgramName}} templates validate -f my-template.yaml --local`
+
+	validateLong = `Validate sandbox templates from a file.
+
+The templates are checked locally: the unknown fields, the backend, the volumes and the components.
+Then they are created with the server-side dry-run, so the schema and the webhooks of the cluster check them too.
+The dry-run does not conflict with the existing templates of the same names.`
+)
+
+type validate struct {
+	filename string
+	local    bool
+}
+
+func newValidateCommand() *cobra.Command {
+	v := &validate{}
+
+	cmd := &cobra.Command{
+		Use:     "validate -f FILE",
+		Short:   "Validate sandbox templates from a file",
+		Example: validateExample,
+		Long:    validateLong,
+		Args:    cobra.NoArgs,
+
+		RunE: v.Run,
+	}
+
+	cmd.Flags().StringVarP(&v.filename, "filename", "f", "", "The yaml or json file with the templates, - is the standard input")
+	cmd.Flags().BoolVar(&v.local, "local", false, "Run only the local checks without the server-side dry-run")
+	_ = cmd.MarkFlagRequired("filename")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (v *validate) Run(cmd *cobra.Command, _ []string) error {
+	templates, err := readTemplates(cmd, v.filename)
+	if err != nil {
+		return err
+	}
+
+	var dryRun func(t *v1alpha1.SandboxTemplate) error
+	if !v.local {
+		client, _, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+		if err != nil {
+			return err
+		}
+		dryRun = func(t *v1alpha1.SandboxTemplate) error {
+			// The template is created under the generated name, so the existing template does not conflict.
+			t = t.DeepCopy()
+			t.GenerateName = t.Name + "-"
+			t.Name = ""
+			_, err := client.SandboxTemplates().Create(cmd.Context(), t, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+			return err
+		}
+	}
+
+	invalid := 0
+	for _, t := range templates {
+		err := checkTemplate(t)
+		if err == nil && dryRun != nil {
+			err = dryRun(t)
+		}
+		if err != nil {
+			invalid++
+			cmd.PrintErrf("sandboxtemplate/%s is invalid:\n  %s\n", t.Name, strings.ReplaceAll(err.Error(), "\n", "\n  "))
+			continue
+		}
+		cmd.Printf("sandboxtemplate/%s is valid\n", t.Name)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d templates are invalid", invalid, len(templates))
+	}
+	return nil
+}
+
+// checkTemplate checks the template without the cluster, the checks repeat the schema and the webhook
+// to report all errors at once and before the template is sent.
+func checkTemplate(t *v1alpha1.SandboxTemplate) error {
+	var errs []error
+	if t.Name == "" {
+		errs = append(errs, errors.New("metadata.name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(t.Name) {
+			errs = append(errs, fmt.Errorf("metadata.name: %s", msg))
+		}
+	}
+
+	spec := &t.Spec
+	backends := 0
+	for _, set := range []bool{
+		spec.PodSpec != nil,
+		spec.KubevirtVMISpec != nil,
+		spec.DVPVMSpec != nil,
+		spec.UnstructuredSpec != nil,
+		spec.PluginSpec != nil,
+		len(spec.Components) > 0,
+	} {
+		if set {
+			backends++
+		}
+	}
+	if backends != 1 {
+		errs = append(errs, errors.New("exactly one of podSpec, kubevirtVMISpec, dvpVMSpec, unstructuredSpec, pluginSpec or components must be specified"))
+	}
+
+	switch {
+	case spec.PodSpec != nil:
+		if len(spec.PodSpec.Containers) == 0 {
+			errs = append(errs, errors.New("podSpec.containers must not be empty"))
+		}
+	case spec.UnstructuredSpec != nil, spec.PluginSpec != nil, len(spec.Components) > 0:
+		if len(spec.Volumes) > 0 {
+			errs = append(errs, errors.New("volumes are supported only for podSpec, kubevirtVMISpec and dvpVMSpec, use volumes of the components"))
+		}
+	}
+	if spec.Completion != nil && spec.Completion.Artifacts != nil && spec.PodSpec == nil {
+		errs = append(errs, errors.New("completion.artifacts is supported only for podSpec"))
+	}
+
+	errs = append(errs, checkVolumes("volumes", spec.Volumes)...)
+	errs = append(errs, checkComponents(spec.Components)...)
+	return errors.Join(errs...)
+}
+
+func checkVolumes(path string, volumes []v1alpha1.SandboxVolumeSpec) []error {
+	var errs []error
+	names := make(map[string]bool, len(volumes))
+	for i, volume := range volumes {
+		if volume.Name == "" {
+			errs = append(errs, fmt.Errorf("%s[%d].name is required", path, i))
+		} else if names[volume.Name] {
+			errs = append(errs, fmt.Errorf("%s[%d]: volume %s already exists", path, i, volume.Name))
+		}
+		names[volume.Name] = true
+
+		specs := 0
+		for _, set := range []bool{volume.PVCSpec != nil, volume.DataVolumeSpec != nil, volume.VirtualDiskSpec != nil} {
+			if set {
+				specs++
+			}
+		}
+		if specs != 1 {
+			errs = append(errs, fmt.Errorf("%s[%d]: exactly one of pvcSpec, dataVolumeSpec or virtualDiskSpec must be specified", path, i))
+		}
+	}
+	return errs
+}
+
+// checkComponents checks the components like the webhook, the dependencies are resolved by the same sort,
+// so the duplicate names, the unknown dependencies and the cycles are reported.
+func checkComponents(components []v1alpha1.SandboxComponent) []error {
+	var errs []error
+	if _, err := common.SortComponents(components); err != nil {
+		errs = append(errs, err)
+	}
+
+	for i, component := range components {
+		specs := 0
+		for _, set := range []bool{component.PodSpec != nil, component.KubevirtVMISpec != nil, component.DVPVMSpec != nil} {
+			if set {
+				specs++
+			}
+		}
+		if specs != 1 {
+			errs = append(errs, fmt.Errorf("component %s: exactly one of podSpec, kubevirtVMISpec or dvpVMSpec must be specified", component.Name))
+		}
+		errs = append(errs, checkVolumes(fmt.Sprintf("components[%d].volumes", i), component.Volumes)...)
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+package vnc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	subv1alpha1 "github.com/yaroslavborbat/sandbox-mommy/api/subresources/v1alpha1"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+)
+
+const (
+	example = `  # Open a local VNC port for the sandbox 'my-sandbox':
+  {{ProgramName}} vnc my-sandbox
+  # Listen on the specific port:
+  {{ProgramName}} vnc my-sandbox --port 5900`
+
+	long = `Open a local listening port proxied to the VNC of a virtual machine sandbox.
+
+Connect any VNC viewer to the printed address. The sandbox must be in the running phase.`
+)
+
+type vnc struct {
+	address   string
+	port      int
+	component string
+}
+
+func NewVNCSandboxCommand() *cobra.Command {
+	v := &vnc{}
+
+	cmd := &cobra.Command{
+		Use:               "vnc [Name]",
+		Short:             "Open a VNC connection to a sandbox",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SandboxName,
+		RunE:              v.Run,
+	}
+
+	cmd.Flags().StringVar(&v.address, "address", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&v.port, "port", 0, "Port to listen on, a random free port is used if 0")
+	cmd.Flags().StringVar(&v.component, "component", "", "Component of the multi-component sandbox to connect to")
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (v *vnc) Run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(v.address, strconv.Itoa(v.port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	go func() {
+		<-cmd.Context().Done()
+		_ = listener.Close()
+	}()
+
+	cmd.Printf("VNC of sandbox %s is available on %s, press Ctrl+C to stop.\n", name, listener.Addr().String())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			if err := proxy(conn, name, namespace, v.component, client); err != nil {
+				cmd.PrintErrf("%v\n", err)
+			}
+		}()
+	}
+}
+
+func proxy(conn net.Conn, name, namespace, component string, client kubeclient.Client) error {
+	stream, err := client.Sandboxes(namespace).VNC(name, &subv1alpha1.VNC{Component: component})
+	if err != nil {
+		return err
+	}
+	return stream.Stream(kubeclient.StreamOptions{
+		In:  conn,
+		Out: conn,
+	})
+}
@@ -0,0 +1,90 @@
+package wait
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/completion"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/template"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/waiter"
+)
+
+const (
+	example = `  # Wait until the sandbox 'my-sandbox' is ready:
+  {{ProgramName}} wait my-sandbox --for=Ready --timeout=10m
+  # Wait until the run-to-completion sandbox 'my-job' succeeds:
+  {{ProgramName}} wait my-job --for=Succeeded
+  # Wait until the sandbox 'my-sandbox' is deleted:
+  {{ProgramName}} wait my-sandbox --for=Deleted`
+
+	long = `Wait for a condition of sandboxes.
+
+The conditions are Ready, Succeeded and Deleted. The progress of the sandbox and the events of its resources,
+such as pulling the image or importing the disk, are printed while waiting.
+The command fails with the failure message, if the sandbox fails.`
+)
+
+type wait struct {
+	condition string
+	timeout   time.Duration
+	quiet     bool
+}
+
+func NewWaitSandboxCommand() *cobra.Command {
+	w := &wait{}
+
+	cmd := &cobra.Command{
+		Use:               "wait [Name...]",
+		Short:             "Wait for a condition of sandboxes",
+		Example:           example,
+		Long:              long,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.SandboxNames,
+
+		RunE: w.Run,
+	}
+
+	cmd.Flags().StringVar(&w.condition, "for", string(waiter.ConditionReady), "Condition to wait for: Ready, Succeeded or Deleted")
+	cmd.Flags().DurationVar(&w.timeout, "timeout", 10*time.Minute, "Time to wait for the condition of all sandboxes, zero means no timeout")
+	cmd.Flags().BoolVarP(&w.quiet, "quiet", "q", false, "Do not print the progress")
+
+	_ = cmd.RegisterFlagCompletionFunc("for", completion.Conditions)
+
+	cmd.SetUsageTemplate(template.UsageTemplate())
+	return cmd
+}
+
+func (w *wait) Run(cmd *cobra.Command, args []string) error {
+	condition, err := waiter.ParseCondition(w.condition)
+	if err != nil {
+		return err
+	}
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// The timeout is shared by the sandboxes.
+	var deadline time.Time
+	if w.timeout > 0 {
+		deadline = time.Now().Add(w.timeout)
+	}
+	progress := cmd.ErrOrStderr()
+	if w.quiet {
+		progress = nil
+	}
+
+	for _, name := range args {
+		timeout := time.Duration(0)
+		if !deadline.IsZero() {
+			timeout = max(time.Until(deadline), time.Nanosecond)
+		}
+		if err = waiter.Wait(cmd.Context(), client, namespace, name, condition, timeout, progress); err != nil {
+			return err
+		}
+		cmd.Printf("sandbox/%s condition met: %s\n", name, condition)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/config"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/printer"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/waiter"
+)
+
+// timeout limits the requests of the completion, the shell waits for them.
+const timeout = 5 * time.Second
+
+// SandboxName completes the name of the sandbox in the current namespace as the first argument.
+func SandboxName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return SandboxNames(cmd, args, toComplete)
+}
+
+// SandboxNames completes the names of the sandboxes in the current namespace, the names in the arguments are skipped.
+// The status and the type of the sandbox are the description of the completion.
+func SandboxNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, namespace, err := clientAndNamespace(cmd)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveError
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	sandboxes, err := client.Sandboxes(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, sandbox := range sandboxes.Items {
+		if !strings.HasPrefix(sandbox.Name, toComplete) || slices.Contains(args, sandbox.Name) {
+			continue
+		}
+		completions = append(completions, describe(sandbox.Name, printer.Status(&sandbox), string(sandbox.Status.Type)))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// TemplateNames completes the names of the templates, the status and the type of the template are the description.
+func TemplateNames(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, _, err := clientAndNamespace(cmd)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveError
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	templates, err := client.SandboxTemplates().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, t := range templates.Items {
+		if strings.HasPrefix(t.Name, toComplete) {
+			completions = append(completions, describe(t.Name, printer.TemplateStatus(&t), string(t.Status.Type)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// TemplateName completes the name of the template as the first argument.
+func TemplateName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return TemplateNames(cmd, args, toComplete)
+}
+
+// OutputFormats completes the output formats of the printer.
+func OutputFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{printer.OutputWide, printer.OutputJSON, printer.OutputYAML, printer.OutputName, "jsonpath="}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Conditions completes the conditions of the sandbox to wait for.
+func Conditions(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	conditions := make([]string, 0, len(waiter.Conditions))
+	for _, c := range waiter.Conditions {
+		conditions = append(conditions, string(c))
+	}
+	return conditions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// clientAndNamespace returns the client and the namespace with the defaults of the config file applied,
+// cobra does not run the pre-run hooks of the completion request, which apply them to the commands.
+func clientAndNamespace(cmd *cobra.Command) (kubeclient.Client, string, error) {
+	if err := config.Apply(cmd); err != nil {
+		return nil, "", err
+	}
+	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	return client, namespace, err
+}
+
+func describe(name, status, sandboxType string) string {
+	description := strings.TrimSpace(fmt.Sprintf("%s %s", status, sandboxType))
+	if description == "" {
+		return name
+	}
+	return name + "\t" + description
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
+)
+
+// flagContext is the flag of the kube context, it overrides the current context of the kubeconfig.
+const flagContext = "context"
+
+// FlagProfile is the flag of the profile, its defaults override the defaults of the kube context.
+const FlagProfile = "profile"
+
+// annotationNoDefault is the annotation of the flag, which does not fall back to the defaults.
+const annotationNoDefault = "sandbox.io/no-default"
+
+type key struct{}
+
+var defaultsKey key
+
+// NewContext returns a new Context that stores the defaults as value.
+func NewContext(ctx context.Context, d Defaults) context.Context {
+	return context.WithValue(ctx, defaultsKey, d)
+}
+
+// FromContext returns the defaults stored in the context, they are empty if the config is not applied.
+func FromContext(ctx context.Context) Defaults {
+	d, _ := ctx.Value(defaultsKey).(Defaults)
+	return d
+}
+
+// flags are the flags, which fall back to the defaults.
+// The defaults are skipped, if the conflicting flag is passed, for example the template of run with --image.
+var flags = []struct {
+	name      string
+	value     func(d Defaults) string
+	conflicts []string
+}{
+	{name: "namespace", value: func(d Defaults) string { return d.Namespace }},
+	{name: "template", value: func(d Defaults) string { return d.Template }, conflicts: []string{"image"}},
+	{name: "ttl", value: func(d Defaults) string {
+		if d.TTL == nil {
+			return ""
+		}
+		return d.TTL.Duration.String()
+	}},
+	{name: "ssh-key", value: func(d Defaults) string { return d.SSHKey }},
+}
+
+// Apply sets the flags of the command, which are not passed, to the defaults of the kube context and the profile.
+// The defaults are stored in the context of the command for the values without the flags.
+// It is called before the command runs and by the completions, cobra does not run the pre-run hooks for them.
+func Apply(cmd *cobra.Command) error {
+	profile, _ := cmd.Flags().GetString(FlagProfile)
+	c, err := Load()
+	if err != nil {
+		return err
+	}
+	kubeContext, err := KubeContext(cmd)
+	if err != nil {
+		return err
+	}
+	d, err := c.Resolve(kubeContext, profile)
+	if err != nil {
+		return err
+	}
+
+	fs := cmd.Flags()
+	for _, flag := range flags {
+		f := fs.Lookup(flag.name)
+		value := flag.value(d)
+		if f == nil || f.Changed || value == "" || changed(cmd, flag.conflicts) || len(f.Annotations[annotationNoDefault]) > 0 {
+			continue
+		}
+		if err = fs.Set(flag.name, value); err != nil {
+			return err
+		}
+	}
+
+	cmd.SetContext(NewContext(cmd.Context(), d))
+	return nil
+}
+
+// NoDefault marks the flag of the command, which does not fall back to the defaults,
+// for example the template of delete is the filter instead of the template of the created sandboxes.
+func NoDefault(cmd *cobra.Command, name string) error {
+	return cmd.Flags().SetAnnotation(name, annotationNoDefault, []string{"true"})
+}
+
+// KubeContext returns the name of the kube context of the command: the context flag or the current context of the kubeconfig.
+func KubeContext(cmd *cobra.Command) (string, error) {
+	if f := cmd.Flags().Lookup(flagContext); f != nil && f.Value.String() != "" {
+		return f.Value.String(), nil
+	}
+	return clientconfig.CurrentContextFromContext(cmd.Context())
+}
+
+func changed(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvConfig overrides the path of the config file.
+const EnvConfig = "SANDBOX_CONFIG"
+
+// Config is the config file of the CLI, it keeps the defaults of the flags.
+type Config struct {
+	// Contexts are the defaults of the kube contexts by the name of the context.
+	Contexts map[string]Defaults `json:"contexts,omitempty"`
+	// Profiles are the named defaults selected with --profile, they override the defaults of the context.
+	Profiles map[string]Defaults `json:"profiles,omitempty"`
+}
+
+// Defaults are the values of the flags, which are not passed to the command.
+type Defaults struct {
+	// Namespace is the namespace of the sandboxes.
+	Namespace string `json:"namespace,omitempty"`
+	// Template is the template of the created sandboxes.
+	Template string `json:"template,omitempty"`
+	// TTL is the TTL of the created sandboxes.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// AttachCommand is the command of exec, when the command is not passed, for example the login shell.
+	AttachCommand []string `json:"attachCommand,omitempty"`
+	// SSHKey is the path to the public SSH key to authorize in the virtual machine sandboxes.
+	SSHKey string `json:"sshKey,omitempty"`
+}
+
+const (
+	KeyNamespace     = "namespace"
+	KeyTemplate      = "template"
+	KeyTTL           = "ttl"
+	KeyAttachCommand = "attachCommand"
+	KeySSHKey        = "sshKey"
+)
+
+// Keys are the keys of the defaults, which can be set and got.
+var Keys = []string{KeyNamespace, KeyTemplate, KeyTTL, KeyAttachCommand, KeySSHKey}
+
+// Path returns the path of the config file: $SANDBOX_CONFIG, $XDG_CONFIG_HOME/sandbox/config.yaml
+// or ~/.config/sandbox/config.yaml.
+func Path() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "sandbox", "config.yaml"), nil
+}
+
+// Load reads the config file, the missing file is the empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err = yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the config file, the directory of the file is created if it does not exist.
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Resolve returns the defaults of the kube context overridden by the defaults of the profile.
+func (c *Config) Resolve(kubeContext, profile string) (Defaults, error) {
+	d := c.Contexts[kubeContext]
+	if profile == "" {
+		return d, nil
+	}
+	p, ok := c.Profiles[profile]
+	if !ok {
+		return Defaults{}, fmt.Errorf("profile %q not found in the config", profile)
+	}
+	return d.merge(p), nil
+}
+
+func (d Defaults) merge(o Defaults) Defaults {
+	if o.Namespace != "" {
+		d.Namespace = o.Namespace
+	}
+	if o.Template != "" {
+		d.Template = o.Template
+	}
+	if o.TTL != nil {
+		d.TTL = o.TTL
+	}
+	if len(o.AttachCommand) > 0 {
+		d.AttachCommand = o.AttachCommand
+	}
+	if o.SSHKey != "" {
+		d.SSHKey = o.SSHKey
+	}
+	return d
+}
+
+// Get returns the value of the key, the empty value means the key is not set.
+func (d *Defaults) Get(key string) (string, error) {
+	switch key {
+	case KeyNamespace:
+		return d.Namespace, nil
+	case KeyTemplate:
+		return d.Template, nil
+	case KeyTTL:
+		if d.TTL == nil {
+			return "", nil
+		}
+		return d.TTL.Duration.String(), nil
+	case KeyAttachCommand:
+		return strings.Join(d.AttachCommand, " "), nil
+	case KeySSHKey:
+		return d.SSHKey, nil
+	}
+	return "", unknownKey(key)
+}
+
+// Set sets the value of the key, the empty value unsets the key.
+// The attach command is split into the arguments by the spaces.
+func (d *Defaults) Set(key, value string) error {
+	switch key {
+	case KeyNamespace:
+		d.Namespace = value
+	case KeyTemplate:
+		d.Template = value
+	case KeyTTL:
+		if value == "" {
+			d.TTL = nil
+			return nil
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid ttl %q: %w", value, err)
+		}
+		d.TTL = &metav1.Duration{Duration: ttl}
+	case KeyAttachCommand:
+		d.AttachCommand = strings.Fields(value)
+	case KeySSHKey:
+		d.SSHKey = value
+	default:
+		return unknownKey(key)
+	}
+	return nil
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown key %q, allowed keys: %s", key, strings.Join(Keys, ", "))
+}
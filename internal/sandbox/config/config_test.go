@@ -0,0 +1,149 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolve(t *testing.T) {
+	hour := &metav1.Duration{Duration: time.Hour}
+	day := &metav1.Duration{Duration: 24 * time.Hour}
+	c := &Config{
+		Contexts: map[string]Defaults{
+			"dev": {Namespace: "dev", Template: "ubuntu", TTL: hour, AttachCommand: []string{"bash", "-l"}},
+		},
+		Profiles: map[string]Defaults{
+			"qa":    {Namespace: "qa", TTL: day},
+			"empty": {},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		kubeContext string
+		profile     string
+		want        Defaults
+		wantErr     bool
+	}{
+		{
+			name:        "context",
+			kubeContext: "dev",
+			want:        Defaults{Namespace: "dev", Template: "ubuntu", TTL: hour, AttachCommand: []string{"bash", "-l"}},
+		},
+		{
+			name:        "unknown context",
+			kubeContext: "prod",
+			want:        Defaults{},
+		},
+		{
+			name:        "profile overrides the set values",
+			kubeContext: "dev",
+			profile:     "qa",
+			want:        Defaults{Namespace: "qa", Template: "ubuntu", TTL: day, AttachCommand: []string{"bash", "-l"}},
+		},
+		{
+			name:        "empty profile keeps the context",
+			kubeContext: "dev",
+			profile:     "empty",
+			want:        Defaults{Namespace: "dev", Template: "ubuntu", TTL: hour, AttachCommand: []string{"bash", "-l"}},
+		},
+		{
+			name:        "profile without the context",
+			kubeContext: "prod",
+			profile:     "qa",
+			want:        Defaults{Namespace: "qa", TTL: day},
+		},
+		{
+			name:        "unknown profile",
+			kubeContext: "dev",
+			profile:     "missing",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Resolve(tt.kubeContext, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultsSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial Defaults
+		key     string
+		value   string
+		want    Defaults
+		get     string
+		wantErr bool
+	}{
+		{
+			name:  "namespace",
+			key:   KeyNamespace,
+			value: "dev",
+			want:  Defaults{Namespace: "dev"},
+			get:   "dev",
+		},
+		{
+			name:  "ttl",
+			key:   KeyTTL,
+			value: "90m",
+			want:  Defaults{TTL: &metav1.Duration{Duration: 90 * time.Minute}},
+			get:   "1h30m0s",
+		},
+		{
+			name:    "empty ttl unsets it",
+			initial: Defaults{TTL: &metav1.Duration{Duration: time.Hour}},
+			key:     KeyTTL,
+			want:    Defaults{},
+		},
+		{
+			name:    "invalid ttl",
+			key:     KeyTTL,
+			value:   "a day",
+			wantErr: true,
+		},
+		{
+			name:  "attach command is split by the spaces",
+			key:   KeyAttachCommand,
+			value: " bash  -l ",
+			want:  Defaults{AttachCommand: []string{"bash", "-l"}},
+			get:   "bash -l",
+		},
+		{
+			name:    "unknown key",
+			key:     "image",
+			value:   "ubuntu",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.initial
+			err := d.Set(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(d, tt.want) {
+				t.Errorf("Set() = %+v, want %+v", d, tt.want)
+			}
+			if got, err := d.Get(tt.key); err != nil || got != tt.get {
+				t.Errorf("Get() = (%q, %v), want %q", got, err, tt.get)
+			}
+		})
+	}
+}
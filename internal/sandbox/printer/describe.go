@@ -0,0 +1,90 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+const describeIndent = "  "
+
+// DescribeWriter writes the description of the resource in the format of kubectl describe:
+// the fields are aligned, the nested fields and the sections are indented by the level.
+type DescribeWriter struct {
+	w *tabwriter.Writer
+}
+
+func NewDescribeWriter(out io.Writer) *DescribeWriter {
+	return &DescribeWriter{w: tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)}
+}
+
+// Write writes the line at the level, the tabs of the format separate the aligned columns.
+func (d *DescribeWriter) Write(level int, format string, a ...any) {
+	_, _ = fmt.Fprintf(d.w, strings.Repeat(describeIndent, level)+format, a...)
+}
+
+// Field writes the field with the value, the empty value is written as <none>.
+func (d *DescribeWriter) Field(level int, name string, value any) {
+	s := fmt.Sprint(value)
+	if s == "" {
+		s = none
+	}
+	d.Write(level, "%s:\t%s\n", name, s)
+}
+
+// YAML writes the section with the object marshalled to yaml.
+func (d *DescribeWriter) YAML(level int, name string, obj any) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	d.Write(level, "%s:\n", name)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		// The yaml is not aligned by the tabs, so the writer does not split it into the columns.
+		d.Write(level+1, "%s\n", strings.ReplaceAll(line, "\t", " "))
+	}
+	return nil
+}
+
+// Conditions writes the section with the table of the conditions.
+func (d *DescribeWriter) Conditions(level int, conditions []metav1.Condition) {
+	if len(conditions) == 0 {
+		d.Field(level, "Conditions", "")
+		return
+	}
+	d.Write(level, "Conditions:\n")
+	d.Write(level+1, "Type\tStatus\tReason\tAge\tMessage\n")
+	d.Write(level+1, "----\t------\t------\t---\t-------\n")
+	for _, c := range conditions {
+		d.Write(level+1, "%s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, Age(c.LastTransitionTime), c.Message)
+	}
+}
+
+func (d *DescribeWriter) Flush() error {
+	return d.w.Flush()
+}
+
+// Age returns the human duration since the time, the zero time is unknown.
+func Age(t metav1.Time) string {
+	if t.IsZero() {
+		return unknown
+	}
+	return duration.HumanDuration(time.Since(t.Time))
+}
+
+// FormatLabels returns the sorted labels or annotations in the form key=value, separated by commas.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
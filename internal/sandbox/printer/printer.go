@@ -0,0 +1,195 @@
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+const (
+	OutputWide = "wide"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+	OutputName = "name"
+
+	outputJSONPathPrefix = "jsonpath="
+)
+
+const outputFormats = "wide|json|yaml|name|jsonpath=TEMPLATE"
+
+// OutputUsage is the usage of the output flag.
+const OutputUsage = "Output format. One of: " + outputFormats
+
+// Printer prints the sandboxes in the output format: the table, the wide table, json, yaml, the names or the jsonpath template.
+// The table keeps the widths of its columns, so the rows printed in the watch mode stay aligned.
+type Printer struct {
+	out           io.Writer
+	output        string
+	allNamespaces bool
+	jsonPath      *jsonpath.JSONPath
+	nodes         *nodeResolver
+	widths        []int
+	header        bool
+	printed       bool
+}
+
+// New returns the printer of the output format, the nodes of the wide table are resolved with the rest config.
+func New(out io.Writer, output string, allNamespaces bool, config *rest.Config) (*Printer, error) {
+	p := &Printer{
+		out:           out,
+		output:        output,
+		allNamespaces: allNamespaces,
+	}
+	switch {
+	case output == "", output == OutputJSON, output == OutputYAML, output == OutputName:
+	case output == OutputWide:
+		client, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		p.nodes = &nodeResolver{client: client}
+	case strings.HasPrefix(output, outputJSONPathPrefix):
+		p.jsonPath = jsonpath.New("output").AllowMissingKeys(true)
+		if err := p.jsonPath.Parse(strings.TrimPrefix(output, outputJSONPathPrefix)); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format %q, allowed formats: %s", output, outputFormats)
+	}
+	return p, nil
+}
+
+// IsTable reports whether the sandboxes are printed as the table.
+func (p *Printer) IsTable() bool {
+	return p.output == "" || p.output == OutputWide
+}
+
+// PrintList prints the sandboxes at once, json and yaml are printed as the list.
+func (p *Printer) PrintList(ctx context.Context, sandboxes []v1alpha1.Sandbox) error {
+	switch {
+	case p.IsTable():
+		rows := make([][]string, 0, len(sandboxes))
+		for i := range sandboxes {
+			rows = append(rows, p.row(ctx, &sandboxes[i]))
+		}
+		return p.printRows(p.columns(), rows...)
+	case p.output == OutputName:
+		for i := range sandboxes {
+			if err := p.printName(v1alpha1.SandboxKind, sandboxes[i].Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	list := &v1alpha1.SandboxList{}
+	list.Kind = v1alpha1.SandboxKind + "List"
+	list.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	for _, sandbox := range sandboxes {
+		list.Items = append(list.Items, *withTypeMeta(&sandbox))
+	}
+	return p.printObject(list)
+}
+
+// Print prints the single sandbox, the table header is printed before the first row.
+func (p *Printer) Print(ctx context.Context, sandbox *v1alpha1.Sandbox) error {
+	switch {
+	case p.IsTable():
+		return p.printRows(p.columns(), p.row(ctx, sandbox))
+	case p.output == OutputName:
+		return p.printName(v1alpha1.SandboxKind, sandbox.Name)
+	case p.output == OutputYAML && p.printed:
+		// The documents of the watch are separated.
+		if _, err := fmt.Fprintln(p.out, "---"); err != nil {
+			return err
+		}
+	}
+	p.printed = true
+	return p.printObject(withTypeMeta(sandbox))
+}
+
+func (p *Printer) printName(kind, name string) error {
+	_, err := fmt.Fprintf(p.out, "%s.%s/%s\n", strings.ToLower(kind), v1alpha1.SchemeGroupVersion.Group, name)
+	return err
+}
+
+func (p *Printer) printObject(obj any) error {
+	switch p.output {
+	case OutputJSON:
+		data, err := json.MarshalIndent(obj, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case OutputYAML:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = p.out.Write(data)
+		return err
+	}
+
+	// The template is executed on the generic object, so the list is addressed as in kubectl: {.items[*].metadata.name}.
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var generic any
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+	if err = p.jsonPath.Execute(p.out, generic); err != nil {
+		return fmt.Errorf("failed to execute the jsonpath template: %w", err)
+	}
+	_, err = fmt.Fprintln(p.out)
+	return err
+}
+
+func (p *Printer) printRows(columns []string, rows ...[]string) error {
+	if !p.header {
+		rows = append([][]string{columns}, rows...)
+		p.header = true
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(p.widths) {
+				p.widths = append(p.widths, 0)
+			}
+			p.widths[i] = max(p.widths[i], len(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", p.widths[i]-len(cell)+3))
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(p.out, b.String())
+	return err
+}
+
+// withTypeMeta returns the copy of the sandbox with the type meta, it is dropped by the clientset.
+func withTypeMeta(sandbox *v1alpha1.Sandbox) *v1alpha1.Sandbox {
+	sandbox = sandbox.DeepCopy()
+	sandbox.Kind = v1alpha1.SandboxKind
+	sandbox.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	return sandbox
+}
@@ -0,0 +1,146 @@
+package printer
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/common"
+)
+
+const (
+	none    = "<none>"
+	unknown = "<unknown>"
+)
+
+func (p *Printer) columns() []string {
+	columns := []string{"NAME", "TEMPLATE", "TYPE", "STATUS", "TTL", "AGE"}
+	if p.allNamespaces {
+		columns = append([]string{"NAMESPACE"}, columns...)
+	}
+	if p.output == OutputWide {
+		columns = append(columns, "NODE")
+	}
+	return columns
+}
+
+func (p *Printer) row(ctx context.Context, sandbox *v1alpha1.Sandbox) []string {
+	row := []string{
+		sandbox.Name,
+		orNone(Template(sandbox)),
+		orNone(string(sandbox.Status.Type)),
+		orNone(Status(sandbox)),
+		TTL(sandbox),
+		duration.HumanDuration(time.Since(sandbox.CreationTimestamp.Time)),
+	}
+	if p.allNamespaces {
+		row = append([]string{sandbox.Namespace}, row...)
+	}
+	if p.nodes != nil {
+		row = append(row, p.nodes.node(ctx, sandbox))
+	}
+	return row
+}
+
+// Template returns the name of the template of the sandbox, the inline template has no name.
+func Template(sandbox *v1alpha1.Sandbox) string {
+	if sandbox.Spec.Template == "" && sandbox.Spec.TemplateSpec != nil {
+		return "<inline>"
+	}
+	return sandbox.Spec.Template
+}
+
+// Status returns the reason of the Ready condition of the sandbox.
+func Status(sandbox *v1alpha1.Sandbox) string {
+	for _, c := range sandbox.Status.Conditions {
+		if c.Type == sandboxcondition.TypeReady.String() {
+			return c.Reason
+		}
+	}
+	return ""
+}
+
+// TTL returns the time remaining until the sandbox expires.
+func TTL(sandbox *v1alpha1.Sandbox) string {
+	if sandbox.Spec.TTL.Duration == 0 {
+		return none
+	}
+	remaining := time.Until(sandbox.CreationTimestamp.Add(sandbox.Spec.TTL.Duration))
+	if remaining <= 0 {
+		return "expired"
+	}
+	return duration.HumanDuration(remaining)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return none
+	}
+	return s
+}
+
+var (
+	podResource         = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	kubevirtVMIResource = schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}
+	dvpVMResource       = schema.GroupVersionResource{Group: "virtualization.deckhouse.io", Version: "v1alpha2", Resource: "virtualmachines"}
+)
+
+// nodeResolver finds the nodes of the workloads of the sandboxes, they are not reported in the sandbox status.
+type nodeResolver struct {
+	client dynamic.Interface
+}
+
+func (r *nodeResolver) node(ctx context.Context, sandbox *v1alpha1.Sandbox) string {
+	if sandbox.Status.Type != v1alpha1.SandboxTypeComposite {
+		return r.workloadNode(ctx, sandbox, sandbox.Status.Type)
+	}
+
+	var nodes []string
+	for _, component := range sandbox.Status.Components {
+		node := r.workloadNode(ctx, common.WithComponent(sandbox, component.Name), component.Type)
+		if node != none && !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return none
+	}
+	return strings.Join(nodes, ",")
+}
+
+func (r *nodeResolver) workloadNode(ctx context.Context, sandbox *v1alpha1.Sandbox, sandboxType v1alpha1.SandboxType) string {
+	var (
+		resource schema.GroupVersionResource
+		field    []string
+	)
+	switch sandboxType {
+	case v1alpha1.SandboxTypePod:
+		resource, field = podResource, []string{"spec", "nodeName"}
+	case v1alpha1.SandboxTypeKubevirtVMI:
+		resource, field = kubevirtVMIResource, []string{"status", "nodeName"}
+	case v1alpha1.SandboxTypeDVPVM:
+		resource, field = dvpVMResource, []string{"status", "nodeName"}
+	default:
+		return none
+	}
+
+	obj, err := r.client.Resource(resource).Namespace(common.GetChildNamespace(sandbox)).Get(ctx, common.GetFullName(sandbox), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return none
+		}
+		return unknown
+	}
+	node, _, _ := unstructured.NestedString(obj.Object, field...)
+	return orNone(node)
+}
@@ -0,0 +1,108 @@
+package printer
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxtemplatecondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandboxtemplate-condition"
+)
+
+// PrintTemplateList prints the templates at once, json and yaml are printed as the list.
+// The templates are cluster-wide, so the wide table is the same as the table.
+func (p *Printer) PrintTemplateList(templates []v1alpha1.SandboxTemplate) error {
+	switch {
+	case p.IsTable():
+		rows := make([][]string, 0, len(templates))
+		for _, t := range templates {
+			rows = append(rows, []string{
+				t.Name,
+				orNone(string(t.Status.Type)),
+				orNone(TemplateStatus(&t)),
+				duration.HumanDuration(time.Since(t.CreationTimestamp.Time)),
+			})
+		}
+		return p.printRows([]string{"NAME", "TYPE", "STATUS", "AGE"}, rows...)
+	case p.output == OutputName:
+		for _, t := range templates {
+			if err := p.printName(v1alpha1.SandboxTemplateKind, t.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	list := &v1alpha1.SandboxTemplateList{}
+	list.Kind = v1alpha1.SandboxTemplateKind + "List"
+	list.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	for _, t := range templates {
+		t.Kind = v1alpha1.SandboxTemplateKind
+		t.APIVersion = v1alpha1.SchemeGroupVersion.String()
+		list.Items = append(list.Items, t)
+	}
+	return p.printObject(list)
+}
+
+// TemplateStatus returns the reason of the Ready condition of the template.
+func TemplateStatus(t *v1alpha1.SandboxTemplate) string {
+	for _, c := range t.Status.Conditions {
+		if c.Type == sandboxtemplatecondition.TypeReady.String() {
+			return c.Reason
+		}
+	}
+	return ""
+}
+
+// TemplateSpec writes the spec of the template without the volumes and the table of the volumes.
+func (d *DescribeWriter) TemplateSpec(level int, spec *v1alpha1.SandboxTemplateSpec) error {
+	withoutVolumes := spec.DeepCopy()
+	withoutVolumes.Volumes = nil
+	if err := d.YAML(level, "Spec", withoutVolumes); err != nil {
+		return err
+	}
+
+	if len(spec.Volumes) == 0 {
+		d.Field(level, "Volumes", "")
+		return nil
+	}
+	d.Write(level, "Volumes:\n")
+	d.Write(level+1, "Name\tKind\tSize\n")
+	d.Write(level+1, "----\t----\t----\n")
+	for _, v := range spec.Volumes {
+		kind, size := volumeKindAndSize(v)
+		d.Write(level+1, "%s\t%s\t%s\n", v.Name, kind, orNone(size))
+	}
+	return nil
+}
+
+func volumeKindAndSize(v v1alpha1.SandboxVolumeSpec) (string, string) {
+	var size *resource.Quantity
+	storage := func(requests corev1.ResourceList) *resource.Quantity {
+		if q, ok := requests[corev1.ResourceStorage]; ok {
+			return &q
+		}
+		return nil
+	}
+
+	kind := unknown
+	switch {
+	case v.PVCSpec != nil:
+		kind, size = "PersistentVolumeClaim", storage(v.PVCSpec.Resources.Requests)
+	case v.DataVolumeSpec != nil:
+		kind = "DataVolume"
+		if v.DataVolumeSpec.PVC != nil {
+			size = storage(v.DataVolumeSpec.PVC.Resources.Requests)
+		} else if v.DataVolumeSpec.Storage != nil {
+			size = storage(v.DataVolumeSpec.Storage.Resources.Requests)
+		}
+	case v.VirtualDiskSpec != nil:
+		kind, size = "VirtualDisk", v.VirtualDiskSpec.PersistentVolumeClaim.Size
+	}
+	if size == nil {
+		return kind, ""
+	}
+	return kind, size.String()
+}
@@ -14,8 +14,20 @@ import (
 	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/clientconfig"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/attach"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/completion"
+	cmdconfig "github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/config"
 	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/create"
 	cmddelete "github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/delete"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/describe"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/exec"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/list"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/replay"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/run"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/sessions"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/templates"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/vnc"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/cmds/wait"
+	"github.com/yaroslavborbat/sandbox-mommy/internal/sandbox/config"
 )
 
 const (
@@ -50,6 +62,12 @@ func NewSandboxCommand() *cobra.Command {
 		Long:          long,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		// The completion command is replaced, the default one completes kubectl instead of the plugin.
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+		// The flags, which are not passed, fall back to the defaults of the config file.
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return config.Apply(cmd)
+		},
 	}
 
 	rootCmd.SetOut(os.Stdout)
@@ -57,21 +75,57 @@ func NewSandboxCommand() *cobra.Command {
 	rootCmd.SetContext(clientconfig.NewContext(
 		ctx, kubeclient.DefaultClientConfig(rootCmd.PersistentFlags()),
 	))
+	rootCmd.PersistentFlags().String(config.FlagProfile, "", "Profile of the config file with the defaults of the flags")
+
+	// kubectl runs kubectl_complete-<plugin> with the arguments to complete.
+	if strings.HasPrefix(program, pluginCompletionPrefix) {
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, os.Args[1:]...))
+	}
 
 	rootCmd.AddCommand(
 		create.NewCreateSandboxCommand(),
+		run.NewRunSandboxCommand(),
 		cmddelete.NewDeleteSandboxCommand(),
+		list.NewListSandboxCommand(),
+		list.NewGetSandboxCommand(),
+		describe.NewDescribeSandboxCommand(),
+		wait.NewWaitSandboxCommand(),
 		attach.NewAttachSandboxCommand(),
+		exec.NewExecSandboxCommand(),
+		vnc.NewVNCSandboxCommand(),
+		sessions.NewSessionsSandboxCommand(),
+		replay.NewReplaySandboxCommand(),
+		templates.NewTemplatesCommand(),
+		cmdconfig.NewConfigCommand(),
+		completion.NewCompletionCommand(pluginName(program)),
 	)
 
 	return rootCmd
 }
 
-var programName = getProgram(filepath.Base(os.Args[0]))
+const (
+	pluginPrefix           = "kubectl-"
+	pluginCompletionPrefix = "kubectl_complete-"
+)
+
+var (
+	program     = filepath.Base(os.Args[0])
+	programName = getProgram(program)
+)
 
 func getProgram(program string) string {
-	if strings.HasPrefix(program, "kubectl-") {
-		return fmt.Sprintf("kubectl %s", strings.TrimPrefix(program, "kubectl-"))
+	if plugin := pluginName(program); plugin != "" {
+		return fmt.Sprintf("kubectl %s", plugin)
 	}
 	return program
 }
+
+// pluginName returns the name of the kubectl plugin, if the program is the plugin or its completion.
+func pluginName(program string) string {
+	for _, prefix := range []string{pluginPrefix, pluginCompletionPrefix} {
+		if plugin, ok := strings.CutPrefix(program, prefix); ok {
+			return plugin
+		}
+	}
+	return ""
+}
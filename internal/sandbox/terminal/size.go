@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// SizeQueue sends the size of the terminal on start and on every change of it.
+type SizeQueue struct {
+	fd    int
+	sizes chan remotecommand.TerminalSize
+	done  chan struct{}
+}
+
+func NewSizeQueue(fd int) *SizeQueue {
+	q := &SizeQueue{
+		fd:    fd,
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+	go q.watch()
+	return q
+}
+
+func (q *SizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.done:
+		return nil
+	}
+}
+
+func (q *SizeQueue) Stop() {
+	close(q.done)
+}
+
+func (q *SizeQueue) send() {
+	width, height, err := term.GetSize(q.fd)
+	if err != nil {
+		return
+	}
+	select {
+	case q.sizes <- remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}:
+	case <-q.done:
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func (q *SizeQueue) watch() {
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+
+	q.send()
+	for {
+		select {
+		case <-winch:
+			q.send()
+		case <-q.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package terminal
+
+// watch sends only the initial size, there is no SIGWINCH on Windows.
+func (q *SizeQueue) watch() {
+	q.send()
+}
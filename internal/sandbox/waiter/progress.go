@@ -0,0 +1,131 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+)
+
+const eventRewatchDelay = time.Second
+
+// progress reports the changes of the sandbox status and the events of the sandbox and its child resources.
+// Every line is reported once, the repeated events and the unchanged statuses are skipped.
+type progress struct {
+	ctx     context.Context
+	out     io.Writer
+	events  corev1client.EventsGetter
+	mu      sync.Mutex
+	last    map[string]string
+	watched map[string]bool
+}
+
+func newProgress(ctx context.Context, out io.Writer, events corev1client.EventsGetter) *progress {
+	return &progress{
+		ctx:     ctx,
+		out:     out,
+		events:  events,
+		last:    make(map[string]string),
+		watched: make(map[string]bool),
+	}
+}
+
+func (p *progress) observe(sandbox *v1alpha1.Sandbox) {
+	for _, c := range sandbox.Status.Conditions {
+		if c.Type == sandboxcondition.TypeReady.String() {
+			p.report("condition", joinMessage(c.Reason, c.Message))
+		}
+	}
+	for _, c := range sandbox.Status.Components {
+		p.report("component/"+c.Name, joinMessage(fmt.Sprintf("component %s: %s", c.Name, c.Reason), c.Message))
+	}
+	for _, v := range sandbox.Status.Volumes {
+		status := strings.TrimSpace(fmt.Sprintf("%s %s", v.Phase, v.Progress))
+		p.report("volume/"+v.Component+"/"+v.Name, joinMessage(fmt.Sprintf("%s %s: %s", v.Kind, v.ResourceName, status), v.Message))
+	}
+
+	// The child resources are created in the dedicated namespace, it is known after the sandbox is reconciled.
+	p.watchEvents(sandbox.Namespace, sandbox.UID)
+	if sandbox.Status.Namespace != "" {
+		p.watchEvents(sandbox.Status.Namespace, sandbox.UID)
+	}
+}
+
+func (p *progress) report(key, line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if line == "" || p.last[key] == line {
+		return
+	}
+	p.last[key] = line
+	_, _ = fmt.Fprintf(p.out, "%s %s\n", time.Now().Format(time.TimeOnly), line)
+}
+
+// watchEvents reports the events of the sandbox and of its child resources in the namespace.
+// The names of the child resources contain the UID of the sandbox.
+func (p *progress) watchEvents(namespace string, uid types.UID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.watched[namespace] {
+		return
+	}
+	p.watched[namespace] = true
+
+	go func() {
+		events := p.events.Events(namespace)
+		resourceVersion := ""
+		for p.ctx.Err() == nil {
+			w, err := events.Watch(p.ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+			if err != nil {
+				// The events are optional, the user may be not allowed to watch them.
+				if apierrors.IsForbidden(err) {
+					return
+				}
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-time.After(eventRewatchDelay):
+				}
+				resourceVersion = ""
+				continue
+			}
+			for e := range w.ResultChan() {
+				if e.Type == watch.Error {
+					// The resource version is expired, the events are listed again.
+					resourceVersion = ""
+					break
+				}
+				event, ok := e.Object.(*corev1.Event)
+				if !ok || e.Type == watch.Deleted {
+					continue
+				}
+				resourceVersion = event.ResourceVersion
+				object := event.InvolvedObject
+				if object.UID != uid && !strings.Contains(object.Name, string(uid)) {
+					continue
+				}
+				p.report("event/"+string(event.UID), fmt.Sprintf("%s %s/%s: %s", event.Reason, strings.ToLower(object.Kind), object.Name, strings.TrimSpace(event.Message)))
+			}
+			w.Stop()
+		}
+	}()
+}
+
+func joinMessage(s, message string) string {
+	if message == "" {
+		return s
+	}
+	return s + ": " + message
+}
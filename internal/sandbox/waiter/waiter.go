@@ -0,0 +1,158 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/client/kubeclient"
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+	sandboxcondition "github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1/sandbox-condition"
+)
+
+// Condition is the state of the sandbox to wait for.
+type Condition string
+
+const (
+	ConditionReady     Condition = "Ready"
+	ConditionSucceeded Condition = "Succeeded"
+	ConditionDeleted   Condition = "Deleted"
+)
+
+// ErrTimeout is returned, when the sandbox does not meet the condition before the timeout.
+var ErrTimeout = errors.New("timed out")
+
+// Conditions are the conditions, which can be waited for.
+var Conditions = []Condition{ConditionReady, ConditionSucceeded, ConditionDeleted}
+
+// ParseCondition parses the condition case-insensitively.
+func ParseCondition(s string) (Condition, error) {
+	for _, c := range Conditions {
+		if strings.EqualFold(s, string(c)) {
+			return c, nil
+		}
+	}
+	names := make([]string, 0, len(Conditions))
+	for _, c := range Conditions {
+		names = append(names, string(c))
+	}
+	return "", fmt.Errorf("unknown condition %q, allowed conditions: %s", s, strings.Join(names, ", "))
+}
+
+// Wait waits until the sandbox meets the condition or the timeout expires, zero timeout means no timeout.
+// The progress of the sandbox and the events of it and its child resources are written to out, if it is not nil.
+// The failed sandbox is reported as the error with the failure message.
+func Wait(ctx context.Context, client kubeclient.Client, namespace, name string, condition Condition, timeout time.Duration, out io.Writer) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sandboxes := client.Sandboxes(namespace)
+	if _, err := sandboxes.Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) && condition == ConditionDeleted {
+			return nil
+		}
+		return err
+	}
+
+	var p *progress
+	if out != nil {
+		kube, err := kubernetes.NewForConfig(client.RESTConfig())
+		if err != nil {
+			return err
+		}
+		p = newProgress(ctx, out, kube.CoreV1())
+	}
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return sandboxes.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return sandboxes.Watch(ctx, options)
+		},
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, lw, &v1alpha1.Sandbox{}, nil, func(event watch.Event) (bool, error) {
+		sandbox, ok := event.Object.(*v1alpha1.Sandbox)
+		if !ok {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			if condition == ConditionDeleted {
+				return true, nil
+			}
+			return false, fmt.Errorf("sandbox %s was deleted", name)
+		}
+		if p != nil {
+			p.observe(sandbox)
+		}
+		return isMet(sandbox, condition)
+	})
+	if err != nil && ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w waiting for the sandbox %s to be %s", ErrTimeout, name, condition)
+		}
+		return ctx.Err()
+	}
+	return err
+}
+
+func isMet(sandbox *v1alpha1.Sandbox, condition Condition) (bool, error) {
+	var ready *metav1.Condition
+	for i := range sandbox.Status.Conditions {
+		if sandbox.Status.Conditions[i].Type == sandboxcondition.TypeReady.String() {
+			ready = &sandbox.Status.Conditions[i]
+		}
+	}
+	if ready == nil {
+		return false, nil
+	}
+
+	switch sandboxcondition.Reason(ready.Reason) {
+	case sandboxcondition.ReasonFailed:
+		if condition == ConditionDeleted {
+			return false, nil
+		}
+		return false, fmt.Errorf("sandbox %s failed: %s", sandbox.Name, failureMessage(sandbox, ready))
+	case sandboxcondition.ReasonSucceeded:
+		switch condition {
+		case ConditionSucceeded:
+			return true, nil
+		case ConditionReady:
+			return false, fmt.Errorf("sandbox %s has finished before getting ready: %s", sandbox.Name, ready.Message)
+		}
+	case sandboxcondition.ReasonReady:
+		return condition == ConditionReady && ready.Status == metav1.ConditionTrue, nil
+	}
+	return false, nil
+}
+
+func failureMessage(sandbox *v1alpha1.Sandbox, ready *metav1.Condition) string {
+	if result := sandbox.Status.Result; result != nil && result.Message != "" {
+		return result.Message
+	}
+	if ready.Message != "" {
+		return ready.Message
+	}
+	return "no message"
+}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type BackendClient interface {
+	Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error)
+	Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error)
+	Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error)
+	Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error)
+}
+
+func NewBackendClient(cc grpc.ClientConnInterface) BackendClient {
+	return &backendClient{cc: cc}
+}
+
+type backendClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *backendClient) Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	resp := &InfoResponse{}
+	return resp, c.invoke(ctx, "Info", req, resp)
+}
+
+func (c *backendClient) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	resp := &CreateResponse{}
+	return resp, c.invoke(ctx, "Create", req, resp)
+}
+
+func (c *backendClient) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	resp := &DeleteResponse{}
+	return resp, c.invoke(ctx, "Delete", req, resp)
+}
+
+func (c *backendClient) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	resp := &StatusResponse{}
+	return resp, c.invoke(ctx, "Status", req, resp)
+}
+
+func (c *backendClient) invoke(ctx context.Context, method string, req, resp any) error {
+	return c.cc.Invoke(ctx, fullMethod(method), req, resp, grpc.CallContentSubtype(CodecName))
+}
@@ -0,0 +1,78 @@
+// Package v1alpha1 implements the v1alpha1 protocol of the sandbox backend plugins.
+//
+// A plugin is a gRPC server which manages the sandboxes of a custom type,
+// it is registered in the cluster by the SandboxBackend resource.
+// The messages are encoded as JSON, so a plugin can be written without the protobuf toolchain.
+// The codec is registered under its own name, the content type of the calls is application/grpc+sandbox-plugin-json,
+// so the codecs of the other gRPC services of the process are not replaced.
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/yaroslavborbat/sandbox-mommy/api/core/v1alpha1"
+)
+
+const (
+	ProtocolVersion = "v1alpha1"
+	ServiceName     = "sandbox.plugin." + ProtocolVersion + ".Backend"
+	CodecName       = "sandbox-plugin-json"
+)
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+type InfoRequest struct{}
+
+type InfoResponse struct {
+	// Name is the human-readable name of the plugin.
+	Name string `json:"name"`
+	// ProtocolVersion must be equal to ProtocolVersion.
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+type CreateRequest struct {
+	Sandbox *v1alpha1.Sandbox `json:"sandbox"`
+	// Spec is the plugin-owned spec from the template.
+	Spec json.RawMessage `json:"spec,omitempty"`
+}
+
+type CreateResponse struct{}
+
+type DeleteRequest struct {
+	Sandbox *v1alpha1.Sandbox `json:"sandbox"`
+}
+
+type DeleteResponse struct{}
+
+type StatusRequest struct {
+	Sandbox *v1alpha1.Sandbox `json:"sandbox"`
+	// Spec is the plugin-owned spec from the template.
+	Spec json.RawMessage `json:"spec,omitempty"`
+}
+
+type StatusResponse struct {
+	// Status is the status of the Ready condition of the sandbox.
+	Status metav1.ConditionStatus `json:"status"`
+	// Reason is one of the reasons of the Ready condition, e.g. Pending, Ready or Failed.
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return CodecName
+}
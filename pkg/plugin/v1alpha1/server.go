@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// BackendServer is the interface which a plugin implements.
+// Create and Delete are called on every reconciliation, so they must be idempotent.
+type BackendServer interface {
+	Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error)
+	Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error)
+	Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error)
+	Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error)
+}
+
+func RegisterBackendServer(s grpc.ServiceRegistrar, srv BackendServer) {
+	s.RegisterService(&backendServiceDesc, srv)
+}
+
+var backendServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*BackendServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Info", Handler: newHandler("Info", BackendServer.Info)},
+		{MethodName: "Create", Handler: newHandler("Create", BackendServer.Create)},
+		{MethodName: "Delete", Handler: newHandler("Delete", BackendServer.Delete)},
+		{MethodName: "Status", Handler: newHandler("Status", BackendServer.Status)},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func newHandler[Req, Resp any](method string, call func(BackendServer, context.Context, *Req) (*Resp, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(BackendServer), ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: fullMethod(method),
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(BackendServer), ctx, req.(*Req))
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+func fullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}